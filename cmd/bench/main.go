@@ -0,0 +1,102 @@
+// Records benchmark results and compares them against a previous run.
+//
+//	go test -run '^$' -bench . -benchmem ./... | go run ./cmd/bench record new.json
+//	go run ./cmd/bench compare old.json new.json
+//
+// compare exits with status 1 when a benchmark got slower than the threshold.
+// Names keep their GOMAXPROCS suffix, so only runs with the same -cpu values
+// are compared.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	var err error
+	worse := 0
+	switch os.Args[1] {
+	case "record":
+		err = record(os.Args[2:])
+	case "compare":
+		worse, err = compare(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(2)
+	}
+	if worse > 0 {
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: bench record <file> < go-test-output")
+	fmt.Fprintln(os.Stderr, "       bench compare [-metric ns/op] [-threshold 10] <old> <new>")
+	os.Exit(2)
+}
+
+// reads go test output from stdin, echoes it and saves the results to a file
+func record(args []string) error {
+	if len(args) != 1 {
+		usage()
+	}
+	results, err := ParseResults(io.TeeReader(os.Stdin, os.Stdout))
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no benchmark results found in the input")
+	}
+	err = SaveRecord(args[0], Record{Date: time.Now(), Results: results})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("saved %d results to %s\n", len(results), args[0])
+	return nil
+}
+
+// prints the change of every benchmark between two records, returns how many
+// got worse
+func compare(args []string) (int, error) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	metric := fs.String("metric", "ns/op", "metric to compare")
+	threshold := fs.Float64("threshold", 10, "percent change considered a regression or improvement")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		usage()
+	}
+	old, err := LoadRecord(fs.Arg(0))
+	if err != nil {
+		return 0, err
+	}
+	current, err := LoadRecord(fs.Arg(1))
+	if err != nil {
+		return 0, err
+	}
+	changes := Compare(old, current, *metric, *threshold)
+	worse := 0
+	for _, c := range changes {
+		mark := ""
+		if c.Worse {
+			mark = " worse"
+			worse++
+		} else if c.Better {
+			mark = " better"
+		}
+		fmt.Printf("%-60s %14.0f %14.0f %+8.1f%%%s\n", c.Name, c.Old, c.New, c.Delta, mark)
+	}
+	if worse > 0 {
+		fmt.Printf("%d benchmarks are more than %.0f%% worse\n", worse, *threshold)
+	}
+	return worse, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// results of a benchmark run, saved as json
+type Record struct {
+	Date    time.Time `json:"date"`
+	Results []Result  `json:"results"`
+}
+
+// mean of every metric reported by a benchmark across its runs
+type Result struct {
+	// package and benchmark name as printed, with the GOMAXPROCS suffix, so
+	// runs with different -cpu values are kept apart
+	Name string `json:"name"`
+	Runs int    `json:"runs"`
+	// metric unit like ns/op to its mean value
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// a metric of a benchmark present in both records
+type Change struct {
+	Name   string
+	Old    float64
+	New    float64
+	Delta  float64
+	Worse  bool
+	Better bool
+}
+
+// reads the output of go test -bench, lines that are not results are ignored
+func ParseResults(r io.Reader) ([]Result, error) {
+	pkg := ""
+	sums := make(map[string]*Result)
+	names := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if p, ok := strings.CutPrefix(line, "pkg: "); ok {
+			pkg = strings.TrimSpace(p)
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") || len(fields)%2 != 0 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		name := fields[0]
+		if pkg != "" {
+			name = pkg + "." + name
+		}
+		result, ok := sums[name]
+		if !ok {
+			result = &Result{Name: name, Metrics: make(map[string]float64)}
+			sums[name] = result
+			names = append(names, name)
+		}
+		result.Runs++
+		for i := 2; i < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q in line: %s", fields[i], line)
+			}
+			result.Metrics[fields[i+1]] += value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(names))
+	for _, name := range names {
+		result := sums[name]
+		for unit := range result.Metrics {
+			result.Metrics[unit] /= float64(result.Runs)
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// compares the metric of the benchmarks present in both records in the order of
+// the current one, a change is worse or better when it moves more than threshold percent
+func Compare(old, current Record, metric string, threshold float64) []Change {
+	previous := make(map[string]Result)
+	for _, result := range old.Results {
+		previous[result.Name] = result
+	}
+	changes := make([]Change, 0)
+	for _, result := range current.Results {
+		p, ok := previous[result.Name]
+		if !ok {
+			continue
+		}
+		a, okA := p.Metrics[metric]
+		b, okB := result.Metrics[metric]
+		if !okA || !okB {
+			continue
+		}
+		delta := 0.0
+		if a != 0 {
+			delta = (b - a) / a * 100
+		} else if b != 0 {
+			delta = math.Inf(1)
+		}
+		changes = append(changes, Change{
+			Name:   result.Name,
+			Old:    a,
+			New:    b,
+			Delta:  delta,
+			Worse:  delta > threshold,
+			Better: delta < -threshold,
+		})
+	}
+	return changes
+}
+
+// loads a record saved by SaveRecord
+func LoadRecord(path string) (Record, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return Record{}, err
+	}
+	var r Record
+	err = json.Unmarshal(bytes, &r)
+	if err != nil {
+		return Record{}, err
+	}
+	return r, nil
+}
+
+// saves the record as indented json
+func SaveRecord(path string, r Record) error {
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bytes, '\n'), 0644)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const output = `goos: linux
+pkg: graph/pkg/traverse
+BenchmarkDijkstra/sb-8         	     100	   1200 ns/op	  512 B/op	  10 allocs/op
+BenchmarkDijkstra/sb-8         	     100	   1000 ns/op	  512 B/op	  10 allocs/op
+BenchmarkBfs/sb-8              	     200	    500 ns/op
+PASS
+ok  	graph/pkg/traverse	2.685s
+`
+
+func TestParseResults(t *testing.T) {
+	results, err := ParseResults(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseResults() failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("ParseResults() returned %v results, want %v", len(results), 2)
+	}
+	dijkstra := results[0]
+	if dijkstra.Name != "graph/pkg/traverse.BenchmarkDijkstra/sb-8" || dijkstra.Runs != 2 {
+		t.Fatalf("ParseResults() first result = %v, want two runs of Dijkstra", dijkstra)
+	}
+	if dijkstra.Metrics["ns/op"] != 1100 || dijkstra.Metrics["allocs/op"] != 10 {
+		t.Fatalf("ParseResults() metrics = %v, want the mean of both runs", dijkstra.Metrics)
+	}
+}
+
+func TestParseResultsProcs(t *testing.T) {
+	// go test -cpu 1,2 omits the suffix for 1, sub-benchmarks may end in -<n>
+	output := `pkg: graph/pkg/traverse
+BenchmarkEuler/complete-5         	     100	   1000 ns/op
+BenchmarkEuler/complete-5-2       	     100	    600 ns/op
+BenchmarkEuler/complete-5         	     100	   1200 ns/op
+`
+	results, err := ParseResults(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseResults() failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("ParseResults() = %v, want one result for each GOMAXPROCS", results)
+	}
+	if results[0].Name != "graph/pkg/traverse.BenchmarkEuler/complete-5" || results[0].Metrics["ns/op"] != 1100 {
+		t.Fatalf("ParseResults() first result = %v, want the mean of both runs with one proc", results[0])
+	}
+	if results[1].Name != "graph/pkg/traverse.BenchmarkEuler/complete-5-2" || results[1].Metrics["ns/op"] != 600 {
+		t.Fatalf("ParseResults() second result = %v, want the run with two procs", results[1])
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old := Record{Results: []Result{
+		{Name: "a", Metrics: map[string]float64{"ns/op": 100}},
+		{Name: "b", Metrics: map[string]float64{"ns/op": 100}},
+		{Name: "c", Metrics: map[string]float64{"ns/op": 100}},
+		{Name: "removed", Metrics: map[string]float64{"ns/op": 100}},
+	}}
+	current := Record{Results: []Result{
+		{Name: "a", Metrics: map[string]float64{"ns/op": 150}},
+		{Name: "b", Metrics: map[string]float64{"ns/op": 105}},
+		{Name: "c", Metrics: map[string]float64{"ns/op": 50}},
+		{Name: "added", Metrics: map[string]float64{"ns/op": 100}},
+	}}
+	changes := Compare(old, current, "ns/op", 10)
+	if len(changes) != 3 {
+		t.Fatalf("Compare() returned %v changes, want only the %v benchmarks in both records", len(changes), 3)
+	}
+	if !changes[0].Worse || changes[0].Delta != 50 {
+		t.Fatalf("Compare() a = %+v, want 50%% worse", changes[0])
+	}
+	if changes[1].Worse || changes[1].Better {
+		t.Fatalf("Compare() b = %+v, want no change under the threshold", changes[1])
+	}
+	if !changes[2].Better {
+		t.Fatalf("Compare() c = %+v, want better", changes[2])
+	}
+}
@@ -2,4 +2,9 @@ module graph
 
 go 1.23.0
 
-require github.com/pinguin-frosch/menu v0.0.0-20240908013911-1588370f4591
+require (
+	github.com/pinguin-frosch/menu v0.0.0-20240908013911-1588370f4591
+	golang.org/x/term v0.32.0
+)
+
+require golang.org/x/sys v0.33.0 // indirect
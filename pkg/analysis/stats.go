@@ -0,0 +1,123 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"maps"
+	"slices"
+)
+
+// summary of the size and shape of a graph
+type Stats struct {
+	Nodes              int         `json:"nodes"`
+	Edges              int         `json:"edges"`
+	TotalWeight        int         `json:"total_weight"`
+	MinDegree          int         `json:"min_degree"`
+	MaxDegree          int         `json:"max_degree"`
+	AverageDegree      float64     `json:"average_degree"`
+	DegreeDistribution map[int]int `json:"degree_distribution"`
+	OddNodes           int         `json:"odd_nodes"`
+	DeadendNodes       int         `json:"deadend_nodes"`
+	ParallelEdges      int         `json:"parallel_edges"`
+	// longest shortest path between two nodes of the same component
+	Diameter int `json:"diameter"`
+	// adjacent node pairs over all possible node pairs
+	Density    float64 `json:"density"`
+	Components int     `json:"components"`
+	// lower bound for a route using every edge, PostmanExact indicates it is the optimal length
+	PostmanLowerBound int  `json:"postman_lower_bound"`
+	PostmanExact      bool `json:"postman_exact"`
+}
+
+// computes the stats of the graph
+func GetStats(g graph.View) (Stats, error) {
+	s := Stats{}
+	s.DegreeDistribution = make(map[int]int)
+	nodes := g.GetAllNodes()
+	s.Nodes = len(nodes)
+	s.OddNodes = len(g.GetAllOddNodes())
+	s.DeadendNodes = len(g.GetAllDeadendNodes())
+	s.Components = len(g.ConnectedComponents())
+
+	adjacentPairs := 0
+	degreeSum := 0
+	for i, node := range nodes {
+		degree := g.Degree(node)
+		s.DegreeDistribution[degree]++
+		degreeSum += degree
+		if i == 0 || degree < s.MinDegree {
+			s.MinDegree = degree
+		}
+		if degree > s.MaxDegree {
+			s.MaxDegree = degree
+		}
+		// each edge and pair is seen from both nodes, count it from the smaller id
+		neighbours := make(map[string]int)
+		for _, edge := range g.GetEdges(node) {
+			if edge.From.Id < edge.To.Id {
+				s.Edges++
+				s.TotalWeight += edge.Weight
+				neighbours[edge.To.Id]++
+			}
+		}
+		for _, edges := range neighbours {
+			adjacentPairs++
+			s.ParallelEdges += edges - 1
+		}
+	}
+	if s.Nodes > 0 {
+		s.AverageDegree = float64(degreeSum) / float64(s.Nodes)
+	}
+	if s.Nodes > 1 {
+		s.Density = float64(adjacentPairs) / float64(s.Nodes*(s.Nodes-1)/2)
+	}
+
+	for _, node := range nodes {
+		distances, err := traverse.ShortestDistances(g, node)
+		if err != nil {
+			return Stats{}, err
+		}
+		for _, d := range distances {
+			s.Diameter = max(s.Diameter, d)
+		}
+	}
+
+	bound, exact, err := traverse.PostmanLowerBound(g)
+	if err != nil {
+		return Stats{}, err
+	}
+	s.PostmanLowerBound = bound
+	s.PostmanExact = exact
+	return s, nil
+}
+
+// returns the stats as indented json
+func (s Stats) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// prints the stats, one per line
+func (s Stats) Print() {
+	fmt.Printf("nodes: %d\n", s.Nodes)
+	fmt.Printf("edges: %d\n", s.Edges)
+	fmt.Printf("total weight: %d\n", s.TotalWeight)
+	fmt.Printf("degree: min %d, max %d, average %.2f\n", s.MinDegree, s.MaxDegree, s.AverageDegree)
+	fmt.Print("degree distribution:")
+	for _, degree := range slices.Sorted(maps.Keys(s.DegreeDistribution)) {
+		fmt.Printf(" %d:%d", degree, s.DegreeDistribution[degree])
+	}
+	fmt.Println()
+	fmt.Printf("odd nodes: %d\n", s.OddNodes)
+	fmt.Printf("deadend nodes: %d\n", s.DeadendNodes)
+	fmt.Printf("parallel edges: %d\n", s.ParallelEdges)
+	fmt.Printf("diameter: %d\n", s.Diameter)
+	fmt.Printf("density: %.4f\n", s.Density)
+	fmt.Printf("components: %d\n", s.Components)
+	if s.PostmanExact {
+		fmt.Printf("shortest route using every edge: %d\n", s.PostmanLowerBound)
+	} else {
+		fmt.Printf("shortest route using every edge: at least %d\n", s.PostmanLowerBound)
+	}
+}
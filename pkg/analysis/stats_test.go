@@ -0,0 +1,68 @@
+package analysis_test
+
+import (
+	"graph/pkg/analysis"
+	"graph/pkg/graph"
+	"testing"
+)
+
+func TestGetStats(t *testing.T) {
+	g, _ := newTarjanGraph(t)
+	s, err := analysis.GetStats(g)
+	if err != nil {
+		t.Fatalf("GetStats(g) failed: %v", err)
+	}
+	if s.Nodes != 6 || s.Edges != 7 || s.TotalWeight != 8 {
+		t.Fatalf("GetStats(g) = %v nodes, %v edges, %v weight, want %v, %v, %v", s.Nodes, s.Edges, s.TotalWeight, 6, 7, 8)
+	}
+	if s.ParallelEdges != 1 {
+		t.Fatalf("GetStats(g).ParallelEdges = %v, want %v", s.ParallelEdges, 1)
+	}
+	if s.OddNodes != 2 || s.DeadendNodes != 1 {
+		t.Fatalf("GetStats(g) odd, deadend = %v, %v, want %v, %v", s.OddNodes, s.DeadendNodes, 2, 1)
+	}
+	if s.MinDegree != 1 || s.MaxDegree != 4 || s.DegreeDistribution[2] != 3 {
+		t.Fatalf("GetStats(g) degrees = %v, %v, %v, want min 1, max 4 and three nodes with degree 2", s.MinDegree, s.MaxDegree, s.DegreeDistribution)
+	}
+	// from a or b to e or f
+	if s.Diameter != 3 {
+		t.Fatalf("GetStats(g).Diameter = %v, want %v", s.Diameter, 3)
+	}
+	// the odd nodes are c and f, joined through d
+	if !s.PostmanExact || s.PostmanLowerBound != 8+2 {
+		t.Fatalf("GetStats(g) postman = %v, %v, want %v, %v", s.PostmanLowerBound, s.PostmanExact, 10, true)
+	}
+	if s.Components != 1 {
+		t.Fatalf("GetStats(g).Components = %v, want %v", s.Components, 1)
+	}
+}
+
+func TestGetStatsDisconnected(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d", "e", "f", "g")
+	// two triangles, every node is even but no route can use both
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["a"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["d"], nodes["e"], 2))
+	_ = g.AddEdge(graph.NewEdge(nodes["e"], nodes["f"], 2))
+	_ = g.AddEdge(graph.NewEdge(nodes["f"], nodes["d"], 2))
+	s, err := analysis.GetStats(g)
+	if err != nil {
+		t.Fatalf("GetStats(g) failed: %v", err)
+	}
+	if s.PostmanExact || s.PostmanLowerBound != 3+6 {
+		t.Fatalf("GetStats(g) postman = %v, %v, want %v, %v", s.PostmanLowerBound, s.PostmanExact, 9, false)
+	}
+
+	// an isolated node does not make the graph disconnected for the route
+	g.RemoveNode(nodes["a"])
+	g.RemoveNode(nodes["b"])
+	g.RemoveNode(nodes["c"])
+	s, err = analysis.GetStats(g)
+	if err != nil {
+		t.Fatalf("GetStats(g) failed: %v", err)
+	}
+	if !s.PostmanExact || s.PostmanLowerBound != 6 {
+		t.Fatalf("GetStats(g) postman = %v, %v, want %v, %v", s.PostmanLowerBound, s.PostmanExact, 6, true)
+	}
+}
@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"cmp"
+	"graph/pkg/collections"
+	"graph/pkg/graph"
+	"slices"
+)
+
+type tarjanState struct {
+	g            graph.View
+	time         int
+	disc         map[string]int
+	low          map[string]int
+	edges        *collections.Stack[graph.Edge]
+	bridges      []graph.Edge
+	articulation []graph.Node
+	components   [][]graph.Edge
+}
+
+// runs tarjan's depth first search over every component of the graph
+func newTarjanState(g graph.View) *tarjanState {
+	ts := tarjanState{}
+	ts.g = g
+	ts.disc = make(map[string]int)
+	ts.low = make(map[string]int)
+	ts.edges = collections.NewStack[graph.Edge]()
+	ts.bridges = make([]graph.Edge, 0)
+	ts.articulation = make([]graph.Node, 0)
+	ts.components = make([][]graph.Edge, 0)
+	for _, node := range g.GetAllNodes() {
+		if _, ok := ts.disc[node.Id]; !ok {
+			ts.visit(node, graph.Edge{}, false)
+		}
+	}
+	return &ts
+}
+
+// visits u, coming from its parent through parentEdge unless u is a root
+func (ts *tarjanState) visit(u graph.Node, parentEdge graph.Edge, hasParent bool) {
+	ts.disc[u.Id] = ts.time
+	ts.low[u.Id] = ts.time
+	ts.time++
+	children := 0
+	isArticulation := false
+	for _, edge := range ts.g.GetEdges(u) {
+		v := edge.To
+		// only the edge used to get here is skipped, parallel edges are back edges
+		if hasParent && edge.Id == parentEdge.Id && v.Id == parentEdge.From.Id {
+			continue
+		}
+		if _, ok := ts.disc[v.Id]; !ok {
+			children++
+			ts.edges.Push(edge)
+			ts.visit(v, edge, true)
+			ts.low[u.Id] = min(ts.low[u.Id], ts.low[v.Id])
+			if ts.low[v.Id] > ts.disc[u.Id] {
+				ts.bridges = append(ts.bridges, canonical(edge))
+			}
+			if ts.low[v.Id] >= ts.disc[u.Id] {
+				if hasParent {
+					isArticulation = true
+				}
+				ts.popComponent(edge)
+			}
+		} else if ts.disc[v.Id] < ts.disc[u.Id] {
+			ts.edges.Push(edge)
+			ts.low[u.Id] = min(ts.low[u.Id], ts.disc[v.Id])
+		}
+	}
+	if !hasParent && children > 1 {
+		isArticulation = true
+	}
+	if isArticulation {
+		ts.articulation = append(ts.articulation, u)
+	}
+}
+
+// pops the edges of a biconnected component until reaching the edge that started it
+func (ts *tarjanState) popComponent(until graph.Edge) {
+	component := make([]graph.Edge, 0)
+	for !ts.edges.Empty() {
+		edge, _ := ts.edges.Pop()
+		component = append(component, canonical(edge))
+		if edge.Id == until.Id && edge.From.Id == until.From.Id && edge.To.Id == until.To.Id {
+			break
+		}
+	}
+	slices.SortFunc(component, sortEdges)
+	ts.components = append(ts.components, component)
+}
+
+// returns the edges whose removal disconnects the graph, parallel edges are never bridges
+func Bridges(g graph.View) []graph.Edge {
+	bridges := newTarjanState(g).bridges
+	slices.SortFunc(bridges, sortEdges)
+	return bridges
+}
+
+// returns the nodes whose removal disconnects the graph in ascending order by id
+func ArticulationPoints(g graph.View) []graph.Node {
+	nodes := newTarjanState(g).articulation
+	slices.SortFunc(nodes, func(a, b graph.Node) int {
+		if a.Id < b.Id {
+			return -1
+		} else if a.Id > b.Id {
+			return 1
+		}
+		return 0
+	})
+	return nodes
+}
+
+// returns the edges of each biconnected component, ordered by their first edge
+func BiconnectedComponents(g graph.View) [][]graph.Edge {
+	components := newTarjanState(g).components
+	slices.SortFunc(components, func(a, b []graph.Edge) int {
+		return sortEdges(a[0], b[0])
+	})
+	return components
+}
+
+// returns the edge with its nodes in ascending order by id
+func canonical(edge graph.Edge) graph.Edge {
+	if edge.From.Id > edge.To.Id {
+		return edge.ReversedEdge()
+	}
+	return edge
+}
+
+var sortEdges func(a, b graph.Edge) int = func(a, b graph.Edge) int {
+	if a.From.Id < b.From.Id {
+		return -1
+	} else if a.From.Id > b.From.Id {
+		return 1
+	} else if a.To.Id < b.To.Id {
+		return -1
+	} else if a.To.Id > b.To.Id {
+		return 1
+	}
+	return cmp.Compare(a.Id, b.Id)
+}
@@ -0,0 +1,74 @@
+package analysis_test
+
+import (
+	"graph/pkg/analysis"
+	"graph/pkg/graph"
+	"testing"
+)
+
+// returns a graph with a node for each id, and the nodes by id
+func newGraphWithNodes(t *testing.T, ids ...string) (graph.Graph, map[string]graph.Node) {
+	t.Helper()
+	g := graph.NewGraph()
+	nodes := make(map[string]graph.Node)
+	for _, id := range ids {
+		node, err := graph.NewNode(id)
+		if err != nil {
+			t.Fatalf("NewNode(%v) failed: %v", id, err)
+		}
+		if err := g.AddNode(node); err != nil {
+			t.Fatalf("AddNode(%v) failed: %v", id, err)
+		}
+		nodes[id] = node
+	}
+	return g, nodes
+}
+
+// a triangle a-b-c joined by c-d to d, which has a double street to e and a street to f
+func newTarjanGraph(t *testing.T) (graph.Graph, map[string]graph.Node) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d", "e", "f")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["a"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["d"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["d"], nodes["e"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["e"], nodes["d"], 2))
+	_ = g.AddEdge(graph.NewEdge(nodes["d"], nodes["f"], 1))
+	return g, nodes
+}
+
+func TestBridges(t *testing.T) {
+	g, _ := newTarjanGraph(t)
+	bridges := analysis.Bridges(g)
+	expected := [][2]string{{"c", "d"}, {"d", "f"}}
+	if len(bridges) != len(expected) {
+		t.Fatalf("Bridges() = %v, want %v", bridges, expected)
+	}
+	for i, bridge := range bridges {
+		if bridge.From.Id != expected[i][0] || bridge.To.Id != expected[i][1] {
+			t.Fatalf("Bridges() = %v, want %v", bridges, expected)
+		}
+	}
+}
+
+func TestArticulationPoints(t *testing.T) {
+	g, _ := newTarjanGraph(t)
+	nodes := analysis.ArticulationPoints(g)
+	if len(nodes) != 2 || nodes[0].Id != "c" || nodes[1].Id != "d" {
+		t.Fatalf("ArticulationPoints() = %v, want c and d", nodes)
+	}
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	g, _ := newTarjanGraph(t)
+	components := analysis.BiconnectedComponents(g)
+	sizes := []int{3, 1, 2, 1}
+	if len(components) != len(sizes) {
+		t.Fatalf("BiconnectedComponents() returned %v components, want %v", len(components), len(sizes))
+	}
+	for i, component := range components {
+		if len(component) != sizes[i] {
+			t.Fatalf("BiconnectedComponents()[%v] = %v, want %v edges", i, component, sizes[i])
+		}
+	}
+}
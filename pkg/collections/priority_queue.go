@@ -0,0 +1,91 @@
+package collections
+
+import (
+	"slices"
+)
+
+// binary heap where the item for which less is true compared to all others comes out first
+type PriorityQueue[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	pq := PriorityQueue[T]{}
+	pq.data = make([]T, 0)
+	pq.less = less
+	return &pq
+}
+
+func (pq *PriorityQueue[T]) Push(item T) {
+	pq.data = append(pq.data, item)
+	// move the item up until its parent is not greater
+	i := len(pq.data) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.data[i], pq.data[parent]) {
+			break
+		}
+		pq.data[i], pq.data[parent] = pq.data[parent], pq.data[i]
+		i = parent
+	}
+}
+
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if pq.Empty() {
+		var zero T
+		return zero, false
+	}
+	item := pq.data[0]
+	last := len(pq.data) - 1
+	pq.data[0] = pq.data[last]
+	pq.data = pq.data[:last]
+	// move the new root down until both children are not smaller
+	i := 0
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < len(pq.data) && pq.less(pq.data[left], pq.data[smallest]) {
+			smallest = left
+		}
+		if right < len(pq.data) && pq.less(pq.data[right], pq.data[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		pq.data[i], pq.data[smallest] = pq.data[smallest], pq.data[i]
+		i = smallest
+	}
+	return item, true
+}
+
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if pq.Empty() {
+		var zero T
+		return zero, false
+	}
+	return pq.data[0], true
+}
+
+func (pq *PriorityQueue[T]) Empty() bool {
+	return len(pq.data) == 0
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.data)
+}
+
+// returns a copy of the items in the order they would come out
+func (pq *PriorityQueue[T]) Items() []T {
+	items := slices.Clone(pq.data)
+	slices.SortStableFunc(items, func(a, b T) int {
+		if pq.less(a, b) {
+			return -1
+		} else if pq.less(b, a) {
+			return 1
+		}
+		return 0
+	})
+	return items
+}
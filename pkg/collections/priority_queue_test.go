@@ -0,0 +1,43 @@
+package collections_test
+
+import (
+	"graph/pkg/collections"
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	pq := collections.NewPriorityQueue(func(a, b int) bool { return a < b })
+
+	// pop from empty priority queue
+	n, ok := pq.Pop()
+	if ok {
+		t.Fatalf("pq.Pop() = %v, %v, want %v, %v", n, ok, 0, false)
+	}
+
+	// test ordering behaviour
+	numbers := []int{9, 1, 4, 7, 4, 12, 0, 3}
+	for _, number := range numbers {
+		pq.Push(number)
+	}
+	actualNumber, _ := pq.Peek()
+	if actualNumber != 0 {
+		t.Fatalf("pq.Peek() = %v, want %v", actualNumber, 0)
+	}
+	if pq.Len() != len(numbers) {
+		t.Fatalf("pq.Len() = %v, want %v", pq.Len(), len(numbers))
+	}
+	slices.Sort(numbers)
+	if items := pq.Items(); !slices.Equal(items, numbers) {
+		t.Fatalf("pq.Items() = %v, want %v", items, numbers)
+	}
+	for _, expectedNumber := range numbers {
+		actualNumber, _ := pq.Pop()
+		if expectedNumber != actualNumber {
+			t.Fatalf("pq.Pop() = %v, want %v", actualNumber, expectedNumber)
+		}
+	}
+	if !pq.Empty() {
+		t.Fatalf("pq.Empty() = false, want true")
+	}
+}
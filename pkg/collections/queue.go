@@ -1,5 +1,9 @@
 package collections
 
+import (
+	"slices"
+)
+
 type Queue[T any] struct {
 	data []T
 }
@@ -44,3 +48,8 @@ func (q *Queue[T]) Empty() bool {
 func (q *Queue[T]) Len() int {
 	return len(q.data)
 }
+
+// returns a copy of the items from the front to the back of the queue
+func (q *Queue[T]) Items() []T {
+	return slices.Clone(q.data)
+}
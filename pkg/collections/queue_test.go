@@ -2,6 +2,7 @@ package collections_test
 
 import (
 	"graph/pkg/collections"
+	"slices"
 	"testing"
 )
 
@@ -46,4 +47,14 @@ func TestQueue(t *testing.T) {
 	if queueSize != expectedQueueSize {
 		t.Fatalf("q.Len() = %v, want %v", queueSize, expectedQueueSize)
 	}
+
+	// items are listed from the front and are a copy
+	items := q.Items()
+	if !slices.Equal(items, []int{10, 28, 92}) {
+		t.Fatalf("q.Items() = %v, want %v", items, []int{10, 28, 92})
+	}
+	items[0] = 0
+	if actualNumber, _ := q.Peek(); actualNumber != expectedNumber {
+		t.Fatalf("q.Items() shares its items with the queue")
+	}
 }
@@ -1,5 +1,9 @@
 package collections
 
+import (
+	"slices"
+)
+
 type Stack[T any] struct {
 	data []T
 }
@@ -40,3 +44,8 @@ func (s *Stack[T]) Empty() bool {
 func (s *Stack[T]) Len() int {
 	return len(s.data)
 }
+
+// returns a copy of the items from the bottom to the top of the stack
+func (s *Stack[T]) Items() []T {
+	return slices.Clone(s.data)
+}
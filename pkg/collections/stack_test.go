@@ -48,4 +48,9 @@ func TestStack(t *testing.T) {
 	if queueSize != expectedQueueSize {
 		t.Fatalf("s.Len() = %v, want %v", queueSize, expectedQueueSize)
 	}
+
+	// items are listed from the bottom
+	if items := s.Items(); !slices.Equal(items, []int{81, 2, 10}) {
+		t.Fatalf("s.Items() = %v, want %v", items, []int{81, 2, 10})
+	}
 }
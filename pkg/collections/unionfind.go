@@ -0,0 +1,79 @@
+package collections
+
+type UnionFind[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	sets   int
+}
+
+func NewUnionFind[T comparable]() *UnionFind[T] {
+	uf := UnionFind[T]{}
+	uf.parent = make(map[T]T)
+	uf.rank = make(map[T]int)
+	return &uf
+}
+
+// adds item in its own set, does nothing if it was already added
+func (uf *UnionFind[T]) Add(item T) {
+	if _, ok := uf.parent[item]; ok {
+		return
+	}
+	uf.parent[item] = item
+	uf.rank[item] = 0
+	uf.sets++
+}
+
+// returns the representative of the set containing item, indicates if item was added
+func (uf *UnionFind[T]) Find(item T) (T, bool) {
+	if _, ok := uf.parent[item]; !ok {
+		var zero T
+		return zero, false
+	}
+	root := item
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	// compress the path so next searches are faster
+	for item != root {
+		next := uf.parent[item]
+		uf.parent[item] = root
+		item = next
+	}
+	return root, true
+}
+
+// joins the sets containing a and b, adding them if necessary, indicates if they were in different sets
+func (uf *UnionFind[T]) Union(a, b T) bool {
+	uf.Add(a)
+	uf.Add(b)
+	rootA, _ := uf.Find(a)
+	rootB, _ := uf.Find(b)
+	if rootA == rootB {
+		return false
+	}
+	if uf.rank[rootA] < uf.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	uf.parent[rootB] = rootA
+	if uf.rank[rootA] == uf.rank[rootB] {
+		uf.rank[rootA]++
+	}
+	uf.sets--
+	return true
+}
+
+// indicates if a and b were added and are in the same set
+func (uf *UnionFind[T]) Connected(a, b T) bool {
+	rootA, okA := uf.Find(a)
+	rootB, okB := uf.Find(b)
+	return okA && okB && rootA == rootB
+}
+
+// returns the number of disjoint sets
+func (uf *UnionFind[T]) Sets() int {
+	return uf.sets
+}
+
+func (uf *UnionFind[T]) Len() int {
+	return len(uf.parent)
+}
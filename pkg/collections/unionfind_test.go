@@ -0,0 +1,47 @@
+package collections_test
+
+import (
+	"graph/pkg/collections"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := collections.NewUnionFind[string]()
+
+	// find on missing item
+	_, ok := uf.Find("a")
+	if ok {
+		t.Fatalf(`uf.Find("a") = _, %v, want %v`, ok, false)
+	}
+
+	for _, item := range []string{"a", "b", "c", "d", "e"} {
+		uf.Add(item)
+	}
+	if uf.Sets() != 5 {
+		t.Fatalf("uf.Sets() = %v, want %v", uf.Sets(), 5)
+	}
+
+	// join a-b and c-d, then both groups
+	if !uf.Union("a", "b") || !uf.Union("c", "d") {
+		t.Fatalf("uf.Union() should join different sets")
+	}
+	if uf.Union("b", "a") {
+		t.Fatalf(`uf.Union("b", "a") = true, want false, they are already joined`)
+	}
+	if uf.Connected("a", "c") {
+		t.Fatalf(`uf.Connected("a", "c") = true, want false`)
+	}
+	uf.Union("b", "d")
+	if !uf.Connected("a", "c") {
+		t.Fatalf(`uf.Connected("a", "c") = false, want true`)
+	}
+
+	// union adds missing items
+	uf.Union("e", "f")
+	if uf.Sets() != 2 {
+		t.Fatalf("uf.Sets() = %v, want %v", uf.Sets(), 2)
+	}
+	if uf.Len() != 6 {
+		t.Fatalf("uf.Len() = %v, want %v", uf.Len(), 6)
+	}
+}
@@ -0,0 +1,200 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"graph/pkg/graph"
+	"math"
+	"math/rand/v2"
+)
+
+var (
+	ErrInvalidSize        = errors.New("size of the graph is too small")
+	ErrInvalidProbability = errors.New("probability must be between 0 and 1")
+	ErrInvalidWeights     = errors.New("weights must be at least 1")
+	ErrInvalidRange       = errors.New("max weight is less than min weight")
+)
+
+// distribution of edge weights, it draws from the random source of the generator
+type Weights func(r *rand.Rand) int
+
+// returns the same weight for every edge
+func Constant(weight int) Weights {
+	return func(*rand.Rand) int {
+		return weight
+	}
+}
+
+// returns weights uniformly distributed between min and max, both included,
+// or an error if max is less than min
+func Uniform(min, max int) (Weights, error) {
+	if max < min {
+		return nil, fmt.Errorf("%w: %d < %d", ErrInvalidRange, max, min)
+	}
+	// the size of the range is computed unsigned so wide ranges don't overflow,
+	// it wraps to 0 only when it covers every int
+	size := uint64(max) - uint64(min) + 1
+	return func(r *rand.Rand) int {
+		if size == 0 {
+			return int(r.Uint64())
+		}
+		return min + int(r.Uint64N(size))
+	}, nil
+}
+
+// returns normally distributed weights rounded to the closest integer, never below 1
+func Normal(mean, stddev float64) Weights {
+	return func(r *rand.Rand) int {
+		return max(1, int(math.Round(r.NormFloat64()*stddev+mean)))
+	}
+}
+
+// builds graphs using a seeded random source, the same seed and calls always
+// produce the same graphs
+type Generator struct {
+	rand    *rand.Rand
+	weights Weights
+}
+
+// returns a generator with the given seed, nil weights makes every edge weigh 1
+func NewGenerator(seed uint64, weights Weights) *Generator {
+	if weights == nil {
+		weights = Constant(1)
+	}
+	return &Generator{
+		rand:    rand.New(rand.NewPCG(seed, seed)),
+		weights: weights,
+	}
+}
+
+// returns a graph with n nodes named n0 to n<n-1> and no edges
+func (gen *Generator) nodes(n int) (graph.Graph, []graph.Node, error) {
+	if n < 1 {
+		return graph.Graph{}, nil, fmt.Errorf("%w: %d", ErrInvalidSize, n)
+	}
+	g := graph.NewGraph()
+	nodes := make([]graph.Node, n)
+	for i := range nodes {
+		node, err := graph.NewNode(fmt.Sprintf("n%d", i))
+		if err != nil {
+			return graph.Graph{}, nil, err
+		}
+		_ = g.AddNode(node)
+		nodes[i] = node
+	}
+	return g, nodes, nil
+}
+
+// adds an edge weighted by the generator distribution
+func (gen *Generator) addEdge(g *graph.Graph, from, to graph.Node) error {
+	weight := gen.weights(gen.rand)
+	if weight < 1 {
+		return fmt.Errorf("%w: %d", ErrInvalidWeights, weight)
+	}
+	return g.AddEdge(graph.NewEdge(from, to, weight))
+}
+
+// returns a graph with an edge between every pair of its n nodes
+func (gen *Generator) Complete(n int) (graph.Graph, error) {
+	g, nodes, err := gen.nodes(n)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	for i := range nodes {
+		for j := i + 1; j < n; j++ {
+			err = gen.addEdge(&g, nodes[i], nodes[j])
+			if err != nil {
+				return graph.Graph{}, err
+			}
+		}
+	}
+	return g, nil
+}
+
+// returns n nodes joined one after the other
+func (gen *Generator) Path(n int) (graph.Graph, error) {
+	g, nodes, err := gen.nodes(n)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	for i := 1; i < n; i++ {
+		err = gen.addEdge(&g, nodes[i-1], nodes[i])
+		if err != nil {
+			return graph.Graph{}, err
+		}
+	}
+	return g, nil
+}
+
+// returns a path of n nodes where the last node is joined to the first one, n must be at least 3
+func (gen *Generator) Cycle(n int) (graph.Graph, error) {
+	if n < 3 {
+		return graph.Graph{}, fmt.Errorf("%w: %d", ErrInvalidSize, n)
+	}
+	g, err := gen.Path(n)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	first, _ := g.GetNode("n0")
+	last, _ := g.GetNode(fmt.Sprintf("n%d", n-1))
+	err = gen.addEdge(&g, last, first)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	return g, nil
+}
+
+// returns node n0 joined to each of the other n-1 nodes
+func (gen *Generator) Star(n int) (graph.Graph, error) {
+	g, nodes, err := gen.nodes(n)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	for i := 1; i < n; i++ {
+		err = gen.addEdge(&g, nodes[0], nodes[i])
+		if err != nil {
+			return graph.Graph{}, err
+		}
+	}
+	return g, nil
+}
+
+// returns a city block like lattice, node r<r>c<c> is joined to the nodes
+// above, below and beside it
+func (gen *Generator) Grid(rows, cols int) (graph.Graph, error) {
+	if rows < 1 || cols < 1 {
+		return graph.Graph{}, fmt.Errorf("%w: %dx%d", ErrInvalidSize, rows, cols)
+	}
+	g := graph.NewGraph()
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			node, err := graph.NewNode(fmt.Sprintf("r%dc%d", r, c))
+			if err != nil {
+				return graph.Graph{}, err
+			}
+			_ = g.AddNode(node)
+			if r > 0 {
+				up, _ := g.GetNode(fmt.Sprintf("r%dc%d", r-1, c))
+				err = gen.addEdge(&g, up, node)
+				if err != nil {
+					return graph.Graph{}, err
+				}
+			}
+			if c > 0 {
+				left, _ := g.GetNode(fmt.Sprintf("r%dc%d", r, c-1))
+				err = gen.addEdge(&g, left, node)
+				if err != nil {
+					return graph.Graph{}, err
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+func checkProbability(p float64) error {
+	if p < 0 || p > 1 || math.IsNaN(p) {
+		return fmt.Errorf("%w: %v", ErrInvalidProbability, p)
+	}
+	return nil
+}
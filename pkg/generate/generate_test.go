@@ -0,0 +1,134 @@
+package generate_test
+
+import (
+	"errors"
+	"graph/pkg/generate"
+	"graph/pkg/graph"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func countEdges(g graph.Graph) int {
+	return len(g.GetAllEdges()) / 2
+}
+
+func TestShapes(t *testing.T) {
+	weights, _ := generate.Uniform(1, 9)
+	gen := generate.NewGenerator(1, weights)
+	tests := []struct {
+		name  string
+		build func() (graph.Graph, error)
+		nodes int
+		edges int
+	}{
+		{"Complete(5)", func() (graph.Graph, error) { return gen.Complete(5) }, 5, 10},
+		{"Path(5)", func() (graph.Graph, error) { return gen.Path(5) }, 5, 4},
+		{"Cycle(5)", func() (graph.Graph, error) { return gen.Cycle(5) }, 5, 5},
+		{"Star(5)", func() (graph.Graph, error) { return gen.Star(5) }, 5, 4},
+		{"Grid(3, 4)", func() (graph.Graph, error) { return gen.Grid(3, 4) }, 12, 17},
+	}
+	for _, test := range tests {
+		g, err := test.build()
+		if err != nil {
+			t.Fatalf("%v failed: %v", test.name, err)
+		}
+		if len(g.GetAllNodes()) != test.nodes || countEdges(g) != test.edges {
+			t.Fatalf("%v has %v nodes and %v edges, want %v and %v", test.name, len(g.GetAllNodes()), countEdges(g), test.nodes, test.edges)
+		}
+		if !g.IsConnected() {
+			t.Fatalf("%v should be connected", test.name)
+		}
+		for _, edge := range g.GetAllEdges() {
+			if edge.Weight < 1 || edge.Weight > 9 {
+				t.Fatalf("%v edge %v has weight outside of [1, 9]", test.name, edge.Key())
+			}
+		}
+	}
+
+	if _, err := gen.Cycle(2); err == nil {
+		t.Fatalf("Cycle(2) should fail, a cycle needs 3 nodes")
+	}
+	if _, err := gen.Path(0); err == nil {
+		t.Fatalf("Path(0) should fail")
+	}
+	if _, err := generate.NewGenerator(1, generate.Constant(0)).Path(3); err == nil {
+		t.Fatalf("Path(3) should fail when the weights are not positive")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	a, _ := generate.NewGenerator(42, generate.Normal(10, 3)).ErdosRenyi(30, 0.2)
+	b, _ := generate.NewGenerator(42, generate.Normal(10, 3)).ErdosRenyi(30, 0.2)
+	if !graph.DiffGraphs(a, b).Empty() {
+		t.Fatalf("ErdosRenyi(30, 0.2) with the same seed should build the same graph")
+	}
+	c, _ := generate.NewGenerator(43, generate.Normal(10, 3)).ErdosRenyi(30, 0.2)
+	if graph.DiffGraphs(a, c).Empty() {
+		t.Fatalf("ErdosRenyi(30, 0.2) with different seeds built the same graph")
+	}
+
+	gen := generate.NewGenerator(1, nil)
+	empty, _ := gen.ErdosRenyi(10, 0)
+	full, _ := gen.ErdosRenyi(10, 1)
+	if countEdges(empty) != 0 || countEdges(full) != 45 {
+		t.Fatalf("ErdosRenyi(10, p) should have no edges for p=0 and every edge for p=1")
+	}
+	if _, err := gen.ErdosRenyi(10, 1.5); err == nil {
+		t.Fatalf("ErdosRenyi(10, 1.5) should fail")
+	}
+}
+
+func TestGeometric(t *testing.T) {
+	g, err := generate.NewGenerator(7, nil).Geometric(50, 100, 20)
+	if err != nil {
+		t.Fatalf("Geometric(50, 100, 20) failed: %v", err)
+	}
+	for _, node := range g.GetAllNodes() {
+		if _, ok := node.Attribute("x"); !ok {
+			t.Fatalf("Geometric node %v has no coordinates", node.Id)
+		}
+	}
+	for _, edge := range g.GetAllEdges() {
+		if edge.Weight < 1 || edge.Weight > 20 {
+			t.Fatalf("Geometric edge %v is longer than the radius", edge.Key())
+		}
+	}
+}
+
+func TestEulerian(t *testing.T) {
+	weights, _ := generate.Uniform(1, 5)
+	for seed := range uint64(10) {
+		g, err := generate.NewGenerator(seed, weights).Eulerian(40, 30)
+		if err != nil {
+			t.Fatalf("Eulerian(40, 30) failed: %v", err)
+		}
+		if len(g.GetAllOddNodes()) != 0 || !g.IsConnected() {
+			t.Fatalf("Eulerian(40, 30) with seed %v is not connected with even degrees", seed)
+		}
+		if countEdges(g) < 39+30 {
+			t.Fatalf("Eulerian(40, 30) with seed %v has %v edges, want at least %v", seed, countEdges(g), 39+30)
+		}
+	}
+}
+
+func TestUniform(t *testing.T) {
+	_, err := generate.Uniform(5, 1)
+	if !errors.Is(err, generate.ErrInvalidRange) {
+		t.Fatalf("Uniform(5, 1) error = %v, want %v", err, generate.ErrInvalidRange)
+	}
+
+	// ranges wider than the largest int don't overflow
+	r := rand.New(rand.NewPCG(1, 1))
+	for _, bounds := range [][2]int{{1, 1}, {-3, 3}, {math.MinInt, math.MaxInt}, {-1, math.MaxInt}} {
+		weights, err := generate.Uniform(bounds[0], bounds[1])
+		if err != nil {
+			t.Fatalf("Uniform(%v, %v) failed: %v", bounds[0], bounds[1], err)
+		}
+		for range 100 {
+			if w := weights(r); w < bounds[0] || w > bounds[1] {
+				t.Fatalf("Uniform(%v, %v) returned %v", bounds[0], bounds[1], w)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package generate
+
+import (
+	"fmt"
+	"graph/pkg/graph"
+	"math"
+	"strconv"
+)
+
+// returns an Erdős–Rényi graph, each pair of its n nodes is joined with probability p
+func (gen *Generator) ErdosRenyi(n int, p float64) (graph.Graph, error) {
+	err := checkProbability(p)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	g, nodes, err := gen.nodes(n)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	for i := range nodes {
+		for j := i + 1; j < n; j++ {
+			if gen.rand.Float64() >= p {
+				continue
+			}
+			err = gen.addEdge(&g, nodes[i], nodes[j])
+			if err != nil {
+				return graph.Graph{}, err
+			}
+		}
+	}
+	return g, nil
+}
+
+// returns n nodes placed at random in a square of the given side, nodes closer
+// than radius are joined. The coordinates are stored in the x and y attributes
+// and the weight of each edge is its rounded length, at least 1
+func (gen *Generator) Geometric(n int, side, radius float64) (graph.Graph, error) {
+	g, nodes, err := gen.nodes(n)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i, node := range nodes {
+		xs[i] = gen.rand.Float64() * side
+		ys[i] = gen.rand.Float64() * side
+		node.SetAttribute("x", strconv.FormatFloat(xs[i], 'f', 2, 64))
+		node.SetAttribute("y", strconv.FormatFloat(ys[i], 'f', 2, 64))
+		_ = g.UpdateNode(node)
+	}
+	for i := range nodes {
+		for j := i + 1; j < n; j++ {
+			length := math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+			if length > radius {
+				continue
+			}
+			weight := max(1, int(math.Round(length)))
+			_ = g.AddEdge(graph.NewEdge(nodes[i], nodes[j], weight))
+		}
+	}
+	return g, nil
+}
+
+// returns a connected graph where every node has even degree, so it has an
+// euler circuit. A random spanning tree gets extra random edges and then the
+// odd nodes are joined in random pairs, which may add parallel edges
+func (gen *Generator) Eulerian(n, extra int) (graph.Graph, error) {
+	if n < 3 {
+		return graph.Graph{}, fmt.Errorf("%w: %d", ErrInvalidSize, n)
+	}
+	g, nodes, err := gen.nodes(n)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	degrees := make([]int, n)
+	join := func(i, j int) error {
+		degrees[i]++
+		degrees[j]++
+		return gen.addEdge(&g, nodes[i], nodes[j])
+	}
+	for i := 1; i < n; i++ {
+		err = join(gen.rand.IntN(i), i)
+		if err != nil {
+			return graph.Graph{}, err
+		}
+	}
+	for range extra {
+		i := gen.rand.IntN(n)
+		j := gen.rand.IntN(n - 1)
+		if j >= i {
+			j++
+		}
+		err = join(i, j)
+		if err != nil {
+			return graph.Graph{}, err
+		}
+	}
+	odd := make([]int, 0)
+	for i, degree := range degrees {
+		if degree%2 == 1 {
+			odd = append(odd, i)
+		}
+	}
+	gen.rand.Shuffle(len(odd), func(i, j int) {
+		odd[i], odd[j] = odd[j], odd[i]
+	})
+	for i := 0; i < len(odd); i += 2 {
+		err = join(odd[i], odd[i+1])
+		if err != nil {
+			return graph.Graph{}, err
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// compressed sparse row view of the graph built from the maps, the edges of
+// the node with index i are edges[offsets[i]:offsets[i+1]] ordered by weight
+type adjacency struct {
+	index   *nodeIndex
+	nodes   []Node
+	offsets []int
+	edges   []Edge
+}
+
+// holds the adjacency view until the graph changes, shared by copies of the
+// graph just like the maps. Readers never block once it is built
+type adjacencyCache struct {
+	mu      sync.Mutex
+	current atomic.Pointer[adjacency]
+}
+
+func newAdjacency(g Graph, index *nodeIndex) *adjacency {
+	limit := len(index.ids)
+	a := adjacency{index: index}
+	a.offsets = make([]int, limit+1)
+	for id, neighbours := range g.Edges {
+		i, ok := index.indices[id]
+		if !ok {
+			continue
+		}
+		for _, edges := range neighbours {
+			a.offsets[i+1] += len(edges)
+		}
+	}
+	for i := range limit {
+		a.offsets[i+1] += a.offsets[i]
+	}
+
+	a.edges = make([]Edge, a.offsets[limit])
+	next := slices.Clone(a.offsets[:limit])
+	for id, neighbours := range g.Edges {
+		i, ok := index.indices[id]
+		if !ok {
+			continue
+		}
+		for _, edges := range neighbours {
+			for _, edge := range edges {
+				a.edges[next[i]] = edge
+				next[i]++
+			}
+		}
+	}
+	for i := range limit {
+		slices.SortFunc(a.edges[a.offsets[i]:a.offsets[i+1]], compareAdjacentEdges)
+	}
+
+	a.nodes = make([]Node, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		a.nodes = append(a.nodes, node)
+	}
+	slices.SortFunc(a.nodes, sortNodesById)
+	return &a
+}
+
+// orders by weight like GetEdges always did, ties are broken by the other node and id
+func compareAdjacentEdges(a, b Edge) int {
+	return cmp.Or(cmp.Compare(a.Weight, b.Weight), cmp.Compare(a.To.Id, b.To.Id), cmp.Compare(a.Id, b.Id))
+}
+
+// returns the edges leaving the node, the slice must not be modified
+func (a *adjacency) edgesOf(id string) []Edge {
+	i, ok := a.index.indices[id]
+	if !ok || i+1 >= len(a.offsets) {
+		return nil
+	}
+	return a.edges[a.offsets[i]:a.offsets[i+1]]
+}
+
+// returns the adjacency view, building it if the graph changed since the last call
+func (g Graph) adjacency() *adjacency {
+	if g.adjacencyCache == nil || g.index == nil {
+		// built on every call until init gives the graph a cache
+		return newAdjacency(g, g.getNodeIndex())
+	}
+	if a := g.adjacencyCache.current.Load(); a != nil {
+		return a
+	}
+	g.adjacencyCache.mu.Lock()
+	defer g.adjacencyCache.mu.Unlock()
+	if a := g.adjacencyCache.current.Load(); a != nil {
+		return a
+	}
+	a := newAdjacency(g, g.index)
+	g.adjacencyCache.current.Store(a)
+	return a
+}
+
+// drops the adjacency view, called by every method that changes the graph
+func (g *Graph) invalidate() {
+	if g.adjacencyCache != nil {
+		g.adjacencyCache.current.Store(nil)
+	}
+}
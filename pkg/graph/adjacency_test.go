@@ -0,0 +1,70 @@
+package graph_test
+
+import (
+	"graph/pkg/graph"
+	"testing"
+)
+
+func TestAdjacencyCache(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 3))
+	if len(g.GetEdges(nodes["a"])) != 1 {
+		t.Fatalf("GetEdges(a) = %v, want 1 edge", g.GetEdges(nodes["a"]))
+	}
+
+	// every change must be seen by the next call
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["c"], 1))
+	edges := g.GetEdges(nodes["a"])
+	if len(edges) != 2 || edges[0].To.Id != "c" {
+		t.Fatalf("GetEdges(a) = %v, want the new edge to c first", edges)
+	}
+
+	// the returned slice is a copy
+	edges[0].Weight = 100
+	if g.GetEdges(nodes["a"])[0].Weight != 1 {
+		t.Fatalf("modifying the result of GetEdges(a) changed the graph")
+	}
+
+	g.RemoveEdges(nodes["a"], nodes["c"])
+	if g.Degree(nodes["a"]) != 1 || g.Degree(nodes["c"]) != 0 {
+		t.Fatalf("Degree(a), Degree(c) = %v, %v after RemoveEdges(a, c), want 1, 0", g.Degree(nodes["a"]), g.Degree(nodes["c"]))
+	}
+
+	named := nodes["b"]
+	named.Name = "Plaza"
+	_ = g.UpdateNode(named)
+	if g.GetAllNodes()[1].Name != "Plaza" {
+		t.Fatalf("GetAllNodes() = %v, want the updated name", g.GetAllNodes())
+	}
+
+	// copies share the graph like the maps do
+	h := g
+	g.RemoveNode(nodes["b"])
+	if len(h.GetAllNodes()) != 2 || len(h.GetAllEdges()) != 0 {
+		t.Fatalf("copy of the graph has %v nodes and %v edges, want 2 and 0", len(h.GetAllNodes()), len(h.GetAllEdges()))
+	}
+
+	// a graph not created by NewGraph can still be read
+	var empty graph.Graph
+	if len(empty.GetAllNodes()) != 0 || len(empty.GetEdges(nodes["a"])) != 0 {
+		t.Fatalf("the zero graph should have no nodes or edges")
+	}
+}
+
+func TestGetEdgesOrder(t *testing.T) {
+	g := graph.NewGraph()
+	a, _ := graph.NewNode("a")
+	_ = g.AddNode(a)
+	for _, id := range []string{"d", "c", "b"} {
+		node, _ := graph.NewNode(id)
+		_ = g.AddNode(node)
+		_ = g.AddEdge(graph.NewEdge(a, node, 1))
+	}
+	// ties in weight no longer depend on map iteration
+	for range 10 {
+		edges := g.GetEdges(a)
+		if edges[0].To.Id != "b" || edges[1].To.Id != "c" || edges[2].To.Id != "d" {
+			t.Fatalf("GetEdges(a) = %v, want edges with the same weight ordered by node", edges)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrEdgeNotPresent = errors.New("edge is not present in the graph")
+)
+
+// returns the value of the attribute and if it was set
+func (n Node) Attribute(key string) (string, bool) {
+	value, ok := n.Attributes[key]
+	return value, ok
+}
+
+// sets the attribute, the map is copied so other copies of the node are not affected
+func (n *Node) SetAttribute(key, value string) {
+	attributes := maps.Clone(n.Attributes)
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	attributes[key] = value
+	n.Attributes = attributes
+}
+
+// removes the attribute, the map is copied so other copies of the node are not affected
+func (n *Node) RemoveAttribute(key string) {
+	attributes := maps.Clone(n.Attributes)
+	delete(attributes, key)
+	if len(attributes) == 0 {
+		attributes = nil
+	}
+	n.Attributes = attributes
+}
+
+// returns the name of the node, or its id if it has no name
+func (n Node) Label() string {
+	if n.Name != "" {
+		return n.Name
+	}
+	return n.Id
+}
+
+// returns the value of the attribute and if it was set
+func (e Edge) Attribute(key string) (string, bool) {
+	value, ok := e.Attributes[key]
+	return value, ok
+}
+
+// sets the attribute, the map is copied so other copies of the edge are not affected
+func (e *Edge) SetAttribute(key, value string) {
+	attributes := maps.Clone(e.Attributes)
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	attributes[key] = value
+	e.Attributes = attributes
+}
+
+// removes the attribute, the map is copied so other copies of the edge are not affected
+func (e *Edge) RemoveAttribute(key string) {
+	attributes := maps.Clone(e.Attributes)
+	delete(attributes, key)
+	if len(attributes) == 0 {
+		attributes = nil
+	}
+	e.Attributes = attributes
+}
+
+// returns the name and attributes formatted as {name="..." key=value}, empty if there are none
+func formatMetadata(name string, attributes map[string]string) string {
+	fields := make([]string, 0, len(attributes)+1)
+	if name != "" {
+		fields = append(fields, fmt.Sprintf("name=%q", name))
+	}
+	for _, key := range slices.Sorted(maps.Keys(attributes)) {
+		fields = append(fields, fmt.Sprintf("%s=%s", key, attributes[key]))
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
+// indicates if both have the same name and attributes
+func sameMetadata(aName, bName string, a, b map[string]string) bool {
+	return aName == bName && maps.Equal(a, b)
+}
+
+// replaces the name and attributes of the node with the same id
+func (g *Graph) UpdateNode(node Node) error {
+	if _, ok := g.Nodes[node.Id]; !ok {
+		return nodeError(node.Id, ErrNodeNotPresent)
+	}
+	g.Nodes[node.Id] = node
+	g.invalidate()
+	return nil
+}
+
+// returns the edge with given id between from and to nodes, indicates if it was found
+func (g Graph) GetEdge(from, to Node, id int) (Edge, bool) {
+	edge, ok := g.Edges[from.Id][to.Id][id]
+	return edge, ok
+}
+
+// replaces the weight, name and attributes of the edge with the same id between its nodes
+func (g *Graph) UpdateEdge(edge Edge) error {
+	if _, ok := g.GetEdge(edge.From, edge.To, edge.Id); !ok {
+		return edgeError(edge, ErrEdgeNotPresent)
+	}
+	g.insertEdge(edge)
+	return nil
+}
+
+// returns the nodes where the attribute has the given value, an empty value matches any value
+func (g Graph) GetNodesWithAttribute(key, value string) []Node {
+	nodes := make([]Node, 0)
+	for _, node := range g.GetAllNodes() {
+		if v, ok := node.Attribute(key); ok && (value == "" || v == value) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// returns the edges where the attribute has the given value once, an empty value matches any value
+func (g Graph) GetEdgesWithAttribute(key, value string) []Edge {
+	edges := make([]Edge, 0)
+	for _, edge := range g.GetAllEdges() {
+		if edge.From.Id > edge.To.Id {
+			continue
+		}
+		if v, ok := edge.Attribute(key); ok && (value == "" || v == value) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
@@ -0,0 +1,56 @@
+package graph_test
+
+import (
+	"graph/pkg/graph"
+	"testing"
+)
+
+func TestAttributes(t *testing.T) {
+	g := graph.NewGraph()
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	nodeA.Name = "Calle O'Higgins"
+	nodeA.SetAttribute("kind", "street")
+	_ = g.AddNode(nodeA)
+	_ = g.AddNode(nodeB)
+	edge := graph.NewEdge(nodeA, nodeB, 3)
+	edge.Name = "Avenida Sur"
+	edge.SetAttribute("paved", "yes")
+	_ = g.AddEdge(edge)
+
+	// setting an attribute on a copy must not change the stored node
+	copyA, _ := g.GetNode("a")
+	copyA.SetAttribute("kind", "square")
+	if kind, _ := g.Nodes["a"].Attribute("kind"); kind != "street" {
+		t.Fatalf(`Attribute("kind") = %v, want %v`, kind, "street")
+	}
+
+	loaded, err := graph.NewGraphFromFile(saveGraph(t, g))
+	if err != nil {
+		t.Fatalf("NewGraphFromFile() failed: %v", err)
+	}
+	node, _ := loaded.GetNode("a")
+	if node.Name != nodeA.Name {
+		t.Fatalf("loaded node name = %v, want %v", node.Name, nodeA.Name)
+	}
+	edges := loaded.GetEdgesWithAttribute("paved", "yes")
+	if len(edges) != 1 || edges[0].Name != edge.Name {
+		t.Fatalf(`GetEdgesWithAttribute("paved", "yes") = %v, want the edge named %v`, edges, edge.Name)
+	}
+
+	// both directions of the edge are updated
+	edges[0].RemoveAttribute("paved")
+	err = loaded.UpdateEdge(edges[0])
+	if err != nil {
+		t.Fatalf("UpdateEdge() failed: %v", err)
+	}
+	reversed, _ := loaded.GetEdge(nodeB, nodeA, edges[0].Id)
+	if _, ok := reversed.Attribute("paved"); ok {
+		t.Fatalf(`reversed edge still has attribute "paved"`)
+	}
+
+	nodes := loaded.GetNodesWithAttribute("kind", "")
+	if len(nodes) != 1 || nodes[0].Id != "a" {
+		t.Fatalf(`GetNodesWithAttribute("kind", "") = %v, want only node a`, nodes)
+	}
+}
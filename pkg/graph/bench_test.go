@@ -0,0 +1,80 @@
+package graph_test
+
+import (
+	"graph/pkg/generate"
+	"graph/pkg/graph"
+	"testing"
+)
+
+type benchmarkGraph struct {
+	name string
+	g    graph.Graph
+}
+
+// returns generated city grids of growing size and the real city graph
+func benchmarkGraphs(b *testing.B) []benchmarkGraph {
+	b.Helper()
+	graphs := make([]benchmarkGraph, 0)
+	weights, _ := generate.Uniform(1, 100)
+	gen := generate.NewGenerator(1, weights)
+	for _, size := range []struct {
+		name       string
+		rows, cols int
+	}{{"grid-10x10", 10, 10}, {"grid-30x30", 30, 30}, {"grid-100x100", 100, 100}} {
+		g, err := gen.Grid(size.rows, size.cols)
+		if err != nil {
+			b.Fatalf("Grid(%v, %v) failed: %v", size.rows, size.cols, err)
+		}
+		graphs = append(graphs, benchmarkGraph{size.name, g})
+	}
+	g, err := graph.NewGraphFromFile("../../graphs/sb.json")
+	if err != nil {
+		b.Fatalf("NewGraphFromFile(sb.json) failed: %v", err)
+	}
+	return append(graphs, benchmarkGraph{"sb", g})
+}
+
+func BenchmarkAddEdge(b *testing.B) {
+	for _, bg := range benchmarkGraphs(b) {
+		edges := make([]graph.Edge, 0)
+		for _, edge := range bg.g.GetAllEdges() {
+			if edge.From.Id < edge.To.Id {
+				edges = append(edges, edge)
+			}
+		}
+		b.Run(bg.name, func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				g := graph.NewGraph()
+				for _, node := range bg.g.GetAllNodes() {
+					_ = g.AddNode(node)
+				}
+				b.StartTimer()
+				for _, edge := range edges {
+					_ = g.AddEdge(edge)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetEdges(b *testing.B) {
+	for _, bg := range benchmarkGraphs(b) {
+		nodes := bg.g.GetAllNodes()
+		b.Run(bg.name, func(b *testing.B) {
+			for i := range b.N {
+				bg.g.GetEdges(nodes[i%len(nodes)])
+			}
+		})
+	}
+}
+
+func BenchmarkClone(b *testing.B) {
+	for _, bg := range benchmarkGraphs(b) {
+		b.Run(bg.name, func(b *testing.B) {
+			for range b.N {
+				bg.g.Clone()
+			}
+		})
+	}
+}
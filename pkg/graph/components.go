@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"graph/pkg/collections"
+	"slices"
+)
+
+// returns the nodes reachable from node, including itself, in ascending order by id
+func (g Graph) ReachableNodes(node Node) []Node {
+	return reachableNodes(g, node, g.GetNodes)
+}
+
+// returns the connected components of the graph, each one in ascending order by id,
+// the components are ordered by their first node
+func (g Graph) ConnectedComponents() [][]Node {
+	return connectedComponents(g)
+}
+
+// returns the component number of each node, numbered as in ConnectedComponents
+func (g Graph) ComponentLabels() map[string]int {
+	return componentLabels(g)
+}
+
+// indicates if every node can be reached from any other node, an empty graph is connected
+func (g Graph) IsConnected() bool {
+	return len(g.ConnectedComponents()) <= 1
+}
+
+// returns the nodes reachable from node going to the neighbours of each one
+func reachableNodes(v View, node Node, neighbours func(Node) []Node) []Node {
+	node, err := v.GetNode(node.Id)
+	if err != nil {
+		return []Node{}
+	}
+	visited := map[string]bool{node.Id: true}
+	reachable := []Node{node}
+	q := collections.NewQueue[Node]()
+	q.Enqueue(node)
+	for !q.Empty() {
+		x, _ := q.Dequeue()
+		for _, y := range neighbours(x) {
+			if !visited[y.Id] {
+				visited[y.Id] = true
+				y, _ = v.GetNode(y.Id)
+				reachable = append(reachable, y)
+				q.Enqueue(y)
+			}
+		}
+	}
+	slices.SortFunc(reachable, sortNodesById)
+	return reachable
+}
+
+// returns the components of the view, nodes of a directed view are in the same
+// component when they are joined by arcs in any direction
+func connectedComponents(v View) [][]Node {
+	neighbours := undirectedNeighbours(v)
+	components := make([][]Node, 0)
+	labelled := make(map[string]bool)
+	for _, node := range v.GetAllNodes() {
+		if labelled[node.Id] {
+			continue
+		}
+		component := reachableNodes(v, node, neighbours)
+		for _, n := range component {
+			labelled[n.Id] = true
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+func componentLabels(v View) map[string]int {
+	labels := make(map[string]int)
+	for i, component := range connectedComponents(v) {
+		for _, node := range component {
+			labels[node.Id] = i
+		}
+	}
+	return labels
+}
+
+// returns a function listing the nodes joined to a node by an edge, in a
+// directed view the arcs arriving at the node count too
+func undirectedNeighbours(v View) func(Node) []Node {
+	if !v.IsDirected() {
+		return v.GetNodes
+	}
+	neighbours := make(map[string][]Node)
+	for _, edge := range v.GetAllEdges() {
+		neighbours[edge.From.Id] = append(neighbours[edge.From.Id], edge.To)
+		neighbours[edge.To.Id] = append(neighbours[edge.To.Id], edge.From)
+	}
+	return func(node Node) []Node {
+		return neighbours[node.Id]
+	}
+}
@@ -0,0 +1,38 @@
+package graph_test
+
+import (
+	"graph/pkg/graph"
+	"testing"
+)
+
+func TestConnectedComponents(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d", "e")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["c"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["d"], 1))
+
+	if g.IsConnected() {
+		t.Fatalf("IsConnected() should be false")
+	}
+	components := g.ConnectedComponents()
+	expected := [][]string{{"a", "c"}, {"b", "d"}, {"e"}}
+	if len(components) != len(expected) {
+		t.Fatalf("ConnectedComponents() returned %v components, want %v", len(components), len(expected))
+	}
+	for i, component := range components {
+		for j, node := range component {
+			if node.Id != expected[i][j] {
+				t.Fatalf("ConnectedComponents() = %v, want %v", components, expected)
+			}
+		}
+	}
+	labels := g.ComponentLabels()
+	if labels["a"] != labels["c"] || labels["a"] == labels["b"] || labels["e"] != 2 {
+		t.Fatalf("ComponentLabels() = %v, inconsistent with %v", labels, expected)
+	}
+
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["d"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["e"], nodes["a"], 1))
+	if !g.IsConnected() {
+		t.Fatalf("IsConnected() should be true")
+	}
+}
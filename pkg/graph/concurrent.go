@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// graph safe for concurrent use. Readers get immutable snapshots, so traversals
+// can run while a writer edits and never see a change applied halfway. Every
+// write copies the graph, so even a single AddEdge takes O(V+E), group several
+// changes with Update to copy it once
+type ConcurrentGraph struct {
+	// serializes writers, readers never take it
+	mu      sync.Mutex
+	current atomic.Pointer[Graph]
+}
+
+// returns a concurrent graph holding a copy of g
+func NewConcurrentGraph(g Graph) *ConcurrentGraph {
+	cg := ConcurrentGraph{}
+	clone := g.Clone()
+	cg.current.Store(&clone)
+	return &cg
+}
+
+// returns the current state of the graph, it must not be modified. Later
+// writes do not affect it
+func (cg *ConcurrentGraph) Snapshot() Graph {
+	return *cg.current.Load()
+}
+
+// returns a new concurrent graph starting from the current state, both
+// share the snapshot until one of them is written so it takes constant time
+func (cg *ConcurrentGraph) Clone() *ConcurrentGraph {
+	clone := ConcurrentGraph{}
+	clone.current.Store(cg.current.Load())
+	return &clone
+}
+
+// applies all changes made by fn to a copy of the graph and publishes it at
+// once, nothing is published if fn returns an error
+func (cg *ConcurrentGraph) Update(fn func(g *Graph) error) error {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	next := cg.current.Load().Clone()
+	err := fn(&next)
+	if err != nil {
+		return err
+	}
+	cg.current.Store(&next)
+	return nil
+}
+
+// adds a node to the graph
+func (cg *ConcurrentGraph) AddNode(node Node) error {
+	return cg.Update(func(g *Graph) error {
+		return g.AddNode(node)
+	})
+}
+
+// removes a node from the graph and all its edges
+func (cg *ConcurrentGraph) RemoveNode(node Node) {
+	cg.Update(func(g *Graph) error {
+		g.RemoveNode(node)
+		return nil
+	})
+}
+
+// replaces the name and attributes of the node with the same id
+func (cg *ConcurrentGraph) UpdateNode(node Node) error {
+	return cg.Update(func(g *Graph) error {
+		return g.UpdateNode(node)
+	})
+}
+
+// adds an edge to the graph, it copies the whole graph in O(V+E) like every write
+func (cg *ConcurrentGraph) AddEdge(edge Edge) error {
+	return cg.Update(func(g *Graph) error {
+		return g.AddEdge(edge)
+	})
+}
+
+// replaces the weight, name and attributes of the edge with the same id between its nodes
+func (cg *ConcurrentGraph) UpdateEdge(edge Edge) error {
+	return cg.Update(func(g *Graph) error {
+		return g.UpdateEdge(edge)
+	})
+}
+
+// removes all edges between from and to nodes
+func (cg *ConcurrentGraph) RemoveEdges(from, to Node) {
+	cg.Update(func(g *Graph) error {
+		g.RemoveEdges(from, to)
+		return nil
+	})
+}
+
+// removes the edge with given id, or returns an error if it does not exist
+func (cg *ConcurrentGraph) RemoveEdgeById(id int) error {
+	return cg.Update(func(g *Graph) error {
+		return g.RemoveEdgeById(id)
+	})
+}
+
+// gets node with given id from the graph, or an error if node does not exist
+func (cg *ConcurrentGraph) GetNode(id string) (Node, error) {
+	g := cg.Snapshot()
+	return g.GetNode(id)
+}
+
+// returns all nodes in the graph in ascending order by id
+func (cg *ConcurrentGraph) GetAllNodes() []Node {
+	g := cg.Snapshot()
+	return g.GetAllNodes()
+}
+
+// returns all edges that are reachable from node ordered by ascending weight
+func (cg *ConcurrentGraph) GetEdges(node Node) []Edge {
+	g := cg.Snapshot()
+	return g.GetEdges(node)
+}
+
+// returns all the edges present in the graph
+func (cg *ConcurrentGraph) GetAllEdges() []Edge {
+	g := cg.Snapshot()
+	return g.GetAllEdges()
+}
+
+// returns the edge with given id in the direction it was added, indicates if it was found
+func (cg *ConcurrentGraph) GetEdgeById(id int) (Edge, bool) {
+	g := cg.Snapshot()
+	return g.GetEdgeById(id)
+}
+
+// indicates if edges can only be followed from their from node, never for a graph
+func (cg *ConcurrentGraph) IsDirected() bool {
+	return false
+}
@@ -0,0 +1,127 @@
+package graph_test
+
+import (
+	"fmt"
+	"graph/pkg/graph"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentGraph(t *testing.T) {
+	cg := graph.NewConcurrentGraph(graph.NewGraph())
+	const nodes = 50
+	for i := range nodes {
+		node, _ := graph.NewNode(fmt.Sprintf("n%d", i))
+		if err := cg.AddNode(node); err != nil {
+			t.Fatalf("AddNode(%v) failed: %v", node.Id, err)
+		}
+	}
+	before := cg.Snapshot()
+	clone := cg.Clone()
+
+	// run with -race, writers and readers share the graph
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range nodes - 1 {
+				from := graph.Node{Id: fmt.Sprintf("n%d", i)}
+				to := graph.Node{Id: fmt.Sprintf("n%d", i+1)}
+				if err := cg.AddEdge(graph.NewEdge(from, to, w+1)); err != nil {
+					t.Errorf("AddEdge(%v, %v) failed: %v", from.Id, to.Id, err)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				node := graph.Node{Id: fmt.Sprintf("n%d", i%nodes)}
+				// every edge is added whole, a node never has more than 4 edges to each side
+				if edges := cg.GetEdges(node); len(edges) > 8 {
+					t.Errorf("GetEdges(%v) returned %v edges", node.Id, len(edges))
+				}
+				snapshot := cg.Snapshot()
+				if len(snapshot.GetAllEdges())%2 != 0 {
+					t.Errorf("snapshot has an edge stored in only one direction")
+				}
+				snapshot.ConnectedComponents()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := len(cg.GetAllEdges())/2, 4*(nodes-1); got != want {
+		t.Fatalf("concurrent AddEdge() added %v edges, want %v", got, want)
+	}
+	ids := make(map[int]bool)
+	for _, edge := range cg.GetAllEdges() {
+		ids[edge.Id] = true
+	}
+	if len(ids) != 4*(nodes-1) {
+		t.Fatalf("concurrent AddEdge() used %v different ids, want %v", len(ids), 4*(nodes-1))
+	}
+
+	// snapshots and clones taken earlier do not see later writes
+	if len(before.GetAllEdges()) != 0 || len(clone.GetAllEdges()) != 0 {
+		t.Fatalf("writes changed a snapshot or clone taken before them")
+	}
+}
+
+func TestConcurrentGraphUpdate(t *testing.T) {
+	cg := graph.NewConcurrentGraph(graph.NewGraph())
+	a, _ := graph.NewNode("a")
+	b, _ := graph.NewNode("b")
+	err := cg.Update(func(g *graph.Graph) error {
+		_ = g.AddNode(a)
+		_ = g.AddNode(b)
+		return g.AddEdge(graph.NewEdge(a, b, 1))
+	})
+	if err != nil || len(cg.GetEdges(a)) != 1 {
+		t.Fatalf("Update() = %v, want both nodes and the edge added", err)
+	}
+
+	// a failed update publishes nothing
+	c, _ := graph.NewNode("c")
+	err = cg.Update(func(g *graph.Graph) error {
+		_ = g.AddNode(c)
+		return g.AddEdge(graph.NewEdge(c, c, 1))
+	})
+	if err == nil {
+		t.Fatalf("Update() should fail with a self edge")
+	}
+	if _, err := cg.GetNode("c"); err == nil {
+		t.Fatalf("Update() failed but node c was added")
+	}
+}
+
+func TestConcurrentGraphNodeIndex(t *testing.T) {
+	cg := graph.NewConcurrentGraph(graph.NewGraph())
+	nodes := make([]graph.Node, 0)
+	for _, id := range []string{"c", "a", "b"} {
+		node, _ := graph.NewNode(id)
+		nodes = append(nodes, node)
+		_ = cg.AddNode(node)
+	}
+	before := cg.Snapshot()
+	indexB, _ := before.NodeIndex("b")
+
+	// writes keep the indices of the nodes, removed ones leave their gap
+	cg.RemoveNode(nodes[0])
+	d, _ := graph.NewNode("d")
+	_ = cg.AddNode(d)
+	after := cg.Snapshot()
+	if i, ok := after.NodeIndex("b"); !ok || i != indexB {
+		t.Fatalf(`NodeIndex("b") after the writes = %v, %v, want %v`, i, ok, indexB)
+	}
+	indexC, _ := before.NodeIndex("c")
+	if node, ok := after.NodeAt(indexC); ok {
+		t.Fatalf("NodeAt(%v) after removing c = %v, want no node", indexC, node)
+	}
+	if i, _ := after.NodeIndex("d"); i != 3 {
+		t.Fatalf(`NodeIndex("d") = %v, want %v`, i, 3)
+	}
+}
@@ -0,0 +1,330 @@
+package graph
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+var (
+	ErrPatchConflict = errors.New("patch does not apply to the graph")
+)
+
+// an edge whose weight changed, Edge holds the new weight
+type Reweight struct {
+	Edge      Edge `json:"edge"`
+	OldWeight int  `json:"old_weight"`
+}
+
+// a node whose name or attributes changed
+type NodeChange struct {
+	Old Node `json:"old"`
+	New Node `json:"new"`
+}
+
+// an edge whose name or attributes changed, weights are tracked by Reweight
+type EdgeChange struct {
+	Old Edge `json:"old"`
+	New Edge `json:"new"`
+}
+
+// structural differences between two graphs, it can be applied as a patch
+type Diff struct {
+	AddedNodes      []Node       `json:"added_nodes"`
+	RemovedNodes    []Node       `json:"removed_nodes"`
+	ChangedNodes    []NodeChange `json:"changed_nodes,omitempty"`
+	AddedEdges      []Edge       `json:"added_edges"`
+	RemovedEdges    []Edge       `json:"removed_edges"`
+	ReweightedEdges []Reweight   `json:"reweighted_edges"`
+	ChangedEdges    []EdgeChange `json:"changed_edges,omitempty"`
+}
+
+func newDiff() Diff {
+	d := Diff{}
+	d.AddedNodes = make([]Node, 0)
+	d.RemovedNodes = make([]Node, 0)
+	d.AddedEdges = make([]Edge, 0)
+	d.RemovedEdges = make([]Edge, 0)
+	d.ReweightedEdges = make([]Reweight, 0)
+	d.ChangedNodes = make([]NodeChange, 0)
+	d.ChangedEdges = make([]EdgeChange, 0)
+	return d
+}
+
+// identifies an edge regardless of its direction, parallel edges differ by id
+type edgeKey struct {
+	from string
+	to   string
+	id   int
+}
+
+// returns the edge with its nodes ordered by id, so both directions look the same
+func canonicalEdge(edge Edge) Edge {
+	if edge.From.Id > edge.To.Id {
+		return edge.ReversedEdge()
+	}
+	return edge
+}
+
+func keyOf(edge Edge) edgeKey {
+	edge = canonicalEdge(edge)
+	return edgeKey{edge.From.Id, edge.To.Id, edge.Id}
+}
+
+// returns every edge in the graph once, in its canonical direction
+func canonicalEdges(g *Graph) map[edgeKey]Edge {
+	edges := make(map[edgeKey]Edge)
+	for _, edge := range g.GetAllEdges() {
+		edges[keyOf(edge)] = canonicalEdge(edge)
+	}
+	return edges
+}
+
+// returns the changes needed to turn the old graph into the current one
+func DiffGraphs(old, current Graph) Diff {
+	d := newDiff()
+	for _, node := range current.GetAllNodes() {
+		oldNode, ok := old.Nodes[node.Id]
+		if !ok {
+			d.AddedNodes = append(d.AddedNodes, node)
+		} else if !sameMetadata(oldNode.Name, node.Name, oldNode.Attributes, node.Attributes) {
+			d.ChangedNodes = append(d.ChangedNodes, NodeChange{Old: oldNode, New: node})
+		}
+	}
+	for _, node := range old.GetAllNodes() {
+		if _, ok := current.Nodes[node.Id]; !ok {
+			d.RemovedNodes = append(d.RemovedNodes, node)
+		}
+	}
+
+	oldEdges := canonicalEdges(&old)
+	currentEdges := canonicalEdges(&current)
+	for key, edge := range currentEdges {
+		oldEdge, ok := oldEdges[key]
+		if !ok {
+			d.AddedEdges = append(d.AddedEdges, edge)
+			continue
+		}
+		if oldEdge.Weight != edge.Weight {
+			d.ReweightedEdges = append(d.ReweightedEdges, Reweight{Edge: edge, OldWeight: oldEdge.Weight})
+		}
+		if !sameMetadata(oldEdge.Name, edge.Name, oldEdge.Attributes, edge.Attributes) {
+			d.ChangedEdges = append(d.ChangedEdges, EdgeChange{Old: oldEdge, New: edge})
+		}
+	}
+	for key, edge := range oldEdges {
+		if _, ok := currentEdges[key]; !ok {
+			d.RemovedEdges = append(d.RemovedEdges, edge)
+		}
+	}
+
+	slices.SortFunc(d.AddedEdges, sortEdgesByKey)
+	slices.SortFunc(d.RemovedEdges, sortEdgesByKey)
+	slices.SortFunc(d.ReweightedEdges, func(a, b Reweight) int {
+		return sortEdgesByKey(a.Edge, b.Edge)
+	})
+	slices.SortFunc(d.ChangedEdges, func(a, b EdgeChange) int {
+		return sortEdgesByKey(a.New, b.New)
+	})
+	return d
+}
+
+var sortEdgesByKey func(a, b Edge) int = func(a, b Edge) int {
+	if a.From.Id != b.From.Id {
+		return sortNodesById(a.From, b.From)
+	}
+	if a.To.Id != b.To.Id {
+		return sortNodesById(a.To, b.To)
+	}
+	return cmp.Compare(a.Id, b.Id)
+}
+
+// indicates if there are no differences
+func (d Diff) Empty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 && len(d.ReweightedEdges) == 0 &&
+		len(d.ChangedNodes) == 0 && len(d.ChangedEdges) == 0
+}
+
+// returns the diff that reverts this one
+func (d Diff) Invert() Diff {
+	inverse := newDiff()
+	inverse.AddedNodes = append(inverse.AddedNodes, d.RemovedNodes...)
+	inverse.RemovedNodes = append(inverse.RemovedNodes, d.AddedNodes...)
+	inverse.AddedEdges = append(inverse.AddedEdges, d.RemovedEdges...)
+	inverse.RemovedEdges = append(inverse.RemovedEdges, d.AddedEdges...)
+	for _, r := range d.ReweightedEdges {
+		edge := r.Edge
+		edge.Weight = r.OldWeight
+		inverse.ReweightedEdges = append(inverse.ReweightedEdges, Reweight{Edge: edge, OldWeight: r.Edge.Weight})
+	}
+	for _, c := range d.ChangedNodes {
+		inverse.ChangedNodes = append(inverse.ChangedNodes, NodeChange{Old: c.New, New: c.Old})
+	}
+	for _, c := range d.ChangedEdges {
+		inverse.ChangedEdges = append(inverse.ChangedEdges, EdgeChange{Old: c.New, New: c.Old})
+	}
+	return inverse
+}
+
+// prints the diff, one change per line
+func (d Diff) Print() {
+	for _, node := range d.AddedNodes {
+		fmt.Printf("+ node %s\n", node.Id)
+	}
+	for _, node := range d.RemovedNodes {
+		fmt.Printf("- node %s\n", node.Id)
+	}
+	for _, edge := range d.AddedEdges {
+		fmt.Printf("+ edge %s-%s[%d](%d)\n", edge.From.Id, edge.To.Id, edge.Id, edge.Weight)
+	}
+	for _, edge := range d.RemovedEdges {
+		fmt.Printf("- edge %s-%s[%d](%d)\n", edge.From.Id, edge.To.Id, edge.Id, edge.Weight)
+	}
+	for _, r := range d.ReweightedEdges {
+		fmt.Printf("~ edge %s-%s[%d](%d -> %d)\n", r.Edge.From.Id, r.Edge.To.Id, r.Edge.Id, r.OldWeight, r.Edge.Weight)
+	}
+	for _, c := range d.ChangedNodes {
+		fmt.Printf("~ node %s%s -> %s\n", c.New.Id, formatMetadata(c.Old.Name, c.Old.Attributes), formatMetadata(c.New.Name, c.New.Attributes))
+	}
+	for _, c := range d.ChangedEdges {
+		fmt.Printf("~ edge %s-%s[%d]%s -> %s\n", c.New.From.Id, c.New.To.Id, c.New.Id, formatMetadata(c.Old.Name, c.Old.Attributes), formatMetadata(c.New.Name, c.New.Attributes))
+	}
+}
+
+// checks that the diff can be applied to the graph without conflicts
+func (g *Graph) checkDiff(d Diff) error {
+	edges := canonicalEdges(g)
+	removedEdges := make(map[edgeKey]bool)
+	removedIds := make(map[int]bool)
+	for _, edge := range d.RemovedEdges {
+		current, ok := edges[keyOf(edge)]
+		if !ok || current.Weight != edge.Weight {
+			return fmt.Errorf("%w: edge %s-%s[%d](%d) not found", ErrPatchConflict, edge.From.Id, edge.To.Id, edge.Id, edge.Weight)
+		}
+		removedEdges[keyOf(edge)] = true
+		removedIds[edge.Id] = true
+	}
+	for _, r := range d.ReweightedEdges {
+		current, ok := edges[keyOf(r.Edge)]
+		if !ok || current.Weight != r.OldWeight {
+			return fmt.Errorf("%w: edge %s-%s[%d](%d) not found", ErrPatchConflict, r.Edge.From.Id, r.Edge.To.Id, r.Edge.Id, r.OldWeight)
+		}
+	}
+
+	for _, c := range d.ChangedEdges {
+		current, ok := edges[keyOf(c.New)]
+		if !ok || !sameMetadata(current.Name, c.Old.Name, current.Attributes, c.Old.Attributes) {
+			return fmt.Errorf("%w: edge %s-%s[%d] metadata differs", ErrPatchConflict, c.New.From.Id, c.New.To.Id, c.New.Id)
+		}
+	}
+
+	nodes := make(map[string]bool)
+	for id := range g.Nodes {
+		nodes[id] = true
+	}
+	for _, node := range d.RemovedNodes {
+		if !nodes[node.Id] {
+			return fmt.Errorf("%w: node %s not found", ErrPatchConflict, node.Id)
+		}
+		for _, edge := range g.GetEdges(node) {
+			if !removedEdges[keyOf(edge)] {
+				return fmt.Errorf("%w: node %s still has edge to %s", ErrPatchConflict, node.Id, edge.To.Id)
+			}
+		}
+		delete(nodes, node.Id)
+	}
+	for _, node := range d.AddedNodes {
+		if nodes[node.Id] {
+			return fmt.Errorf("%w: node %s already exists", ErrPatchConflict, node.Id)
+		}
+		nodes[node.Id] = true
+	}
+	for _, c := range d.ChangedNodes {
+		current, ok := g.Nodes[c.New.Id]
+		if !ok || !nodes[c.New.Id] || !sameMetadata(current.Name, c.Old.Name, current.Attributes, c.Old.Attributes) {
+			return fmt.Errorf("%w: node %s metadata differs", ErrPatchConflict, c.New.Id)
+		}
+	}
+
+	addedIds := make(map[int]bool)
+	for _, edge := range d.AddedEdges {
+		if edge.From.Id == edge.To.Id {
+			return nodeError(edge.From.Id, ErrSelfEdge)
+		}
+		if !nodes[edge.From.Id] || !nodes[edge.To.Id] {
+			return fmt.Errorf("%w: edge %s-%s[%d] has missing nodes", ErrPatchConflict, edge.From.Id, edge.To.Id, edge.Id)
+		}
+		if _, ok := edges[keyOf(edge)]; ok && !removedEdges[keyOf(edge)] {
+			return fmt.Errorf("%w: edge %s-%s[%d] already exists", ErrPatchConflict, edge.From.Id, edge.To.Id, edge.Id)
+		}
+		// edge ids are unique in the whole graph
+		if (g.edgeIndex.usedByOther(edge) && !removedIds[edge.Id]) || addedIds[edge.Id] {
+			return fmt.Errorf("%w: edge id %d is already used", ErrPatchConflict, edge.Id)
+		}
+		addedIds[edge.Id] = true
+	}
+	return nil
+}
+
+// applies the diff as a patch, the graph is left untouched if it doesn't apply
+func (g *Graph) ApplyDiff(d Diff) error {
+	g.init()
+	err := g.checkDiff(d)
+	if err != nil {
+		return err
+	}
+	for _, edge := range d.RemovedEdges {
+		g.removeEdge(edge)
+	}
+	for _, r := range d.ReweightedEdges {
+		edge, _ := g.GetEdge(r.Edge.From, r.Edge.To, r.Edge.Id)
+		edge.Weight = r.Edge.Weight
+		g.insertEdge(edge)
+	}
+	for _, c := range d.ChangedEdges {
+		edge, _ := g.GetEdge(c.New.From, c.New.To, c.New.Id)
+		edge.Name = c.New.Name
+		edge.Attributes = c.New.Attributes
+		g.insertEdge(edge)
+	}
+	for _, node := range d.RemovedNodes {
+		g.RemoveNode(node)
+	}
+	for _, node := range d.AddedNodes {
+		g.AddNode(node)
+	}
+	for _, c := range d.ChangedNodes {
+		g.UpdateNode(c.New)
+	}
+	for _, edge := range d.AddedEdges {
+		g.insertEdge(edge)
+	}
+	return nil
+}
+
+// initializes a diff from given patch file
+func NewDiffFromFile(filename string) (Diff, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return Diff{}, err
+	}
+	d := newDiff()
+	err = json.Unmarshal(bytes, &d)
+	if err != nil {
+		return Diff{}, err
+	}
+	return d, nil
+}
+
+// saves the diff as a patch file, the file is replaced atomically
+func (d Diff) SaveDiffToPath(path string) error {
+	bytes, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, bytes)
+}
@@ -0,0 +1,127 @@
+package graph_test
+
+import (
+	"graph/pkg/graph"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffGraphs(t *testing.T) {
+	old := graph.NewGraph()
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	nodeC, _ := graph.NewNode("c")
+	_ = old.AddNode(nodeA)
+	_ = old.AddNode(nodeB)
+	_ = old.AddNode(nodeC)
+	_ = old.AddEdge(graph.NewEdge(nodeA, nodeB, 1))
+	_ = old.AddEdge(graph.NewEdge(nodeA, nodeB, 1))
+	_ = old.AddEdge(graph.NewEdge(nodeB, nodeC, 4))
+
+	current, err := graph.NewGraphFromFile(saveGraph(t, old))
+	if err != nil {
+		t.Fatalf("NewGraphFromFile() failed: %v", err)
+	}
+	nodeD, _ := graph.NewNode("d")
+	_ = current.AddNode(nodeD)
+	current.RemoveEdgeWithWeight(nodeA, nodeB, 1)
+	current.RemoveEdges(nodeB, nodeC)
+	current.RemoveNode(nodeC)
+	_ = current.AddEdge(graph.NewEdge(nodeD, nodeA, 2))
+
+	d := graph.DiffGraphs(old, current)
+	if len(d.AddedNodes) != 1 || len(d.RemovedNodes) != 1 {
+		t.Fatalf("DiffGraphs() nodes = +%v -%v, want one added and one removed", d.AddedNodes, d.RemovedNodes)
+	}
+	// only one of the parallel edges was removed
+	if len(d.AddedEdges) != 1 || len(d.RemovedEdges) != 2 {
+		t.Fatalf("DiffGraphs() edges = +%v -%v, want one added and two removed", d.AddedEdges, d.RemovedEdges)
+	}
+
+	err = old.ApplyDiff(d)
+	if err != nil {
+		t.Fatalf("ApplyDiff(d) failed: %v", err)
+	}
+	if !graph.DiffGraphs(old, current).Empty() {
+		t.Fatalf("ApplyDiff(d) should reproduce the current graph")
+	}
+
+	// applying it a second time conflicts and leaves the graph untouched
+	err = old.ApplyDiff(d)
+	if err == nil {
+		t.Fatalf("ApplyDiff(d) should fail when applied twice")
+	}
+	if !graph.DiffGraphs(old, current).Empty() {
+		t.Fatalf("ApplyDiff(d) modified the graph even though it failed")
+	}
+
+	err = old.ApplyDiff(d.Invert())
+	if err != nil {
+		t.Fatalf("ApplyDiff(d.Invert()) failed: %v", err)
+	}
+	if len(old.GetAllEdges()) != 6 {
+		t.Fatalf("ApplyDiff(d.Invert()) should restore %v edges, got %v", 6, len(old.GetAllEdges()))
+	}
+}
+
+func TestDiffReweight(t *testing.T) {
+	old := graph.NewGraph()
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	_ = old.AddNode(nodeA)
+	_ = old.AddNode(nodeB)
+	_ = old.AddEdge(graph.NewEdge(nodeA, nodeB, 3))
+
+	current := graph.NewGraph()
+	_ = current.AddNode(nodeA)
+	_ = current.AddNode(nodeB)
+	_ = current.AddEdge(graph.NewEdge(nodeB, nodeA, 8))
+
+	d := graph.DiffGraphs(old, current)
+	if len(d.ReweightedEdges) != 1 || len(d.AddedEdges) != 0 || len(d.RemovedEdges) != 0 {
+		t.Fatalf("DiffGraphs() = %+v, want a single reweighted edge", d)
+	}
+	if d.ReweightedEdges[0].OldWeight != 3 || d.ReweightedEdges[0].Edge.Weight != 8 {
+		t.Fatalf("DiffGraphs() reweighted = %+v, want weight %v -> %v", d.ReweightedEdges[0], 3, 8)
+	}
+
+	path := filepath.Join(t.TempDir(), "patch.json")
+	err := d.SaveDiffToPath(path)
+	if err != nil {
+		t.Fatalf("SaveDiffToPath() failed: %v", err)
+	}
+	patch, err := graph.NewDiffFromFile(path)
+	if err != nil {
+		t.Fatalf("NewDiffFromFile() failed: %v", err)
+	}
+	err = old.ApplyDiff(patch)
+	if err != nil {
+		t.Fatalf("ApplyDiff(patch) failed: %v", err)
+	}
+	edge, _ := old.GetShortestEdge(nodeA, nodeB)
+	if edge.Weight != 8 {
+		t.Fatalf("ApplyDiff(patch) edge weight = %v, want %v", edge.Weight, 8)
+	}
+
+	// edge ids are unique in the whole graph, a patch cannot reuse one
+	nodeC, _ := graph.NewNode("c")
+	conflict := graph.Diff{
+		AddedNodes: []graph.Node{nodeC},
+		AddedEdges: []graph.Edge{{Id: edge.Id, From: nodeA, To: nodeC, Weight: 1}},
+	}
+	err = old.ApplyDiff(conflict)
+	if err == nil {
+		t.Fatalf("ApplyDiff() should fail, edge id %v is used between a and b", edge.Id)
+	}
+}
+
+// saves the graph to a temporary file and returns its path
+func saveGraph(t *testing.T, g graph.Graph) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "graph.json")
+	err := g.SaveGraphToPath(path)
+	if err != nil {
+		t.Fatalf("SaveGraphToPath() failed: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"slices"
+)
+
+// directed graph, each arc can only be followed from its from node to its to node
+type Digraph struct {
+	nodes  map[string]Node
+	arcs   map[string][]Edge
+	nextId int
+}
+
+// initializes an empty directed graph
+func NewDigraph() *Digraph {
+	d := Digraph{}
+	d.nodes = make(map[string]Node)
+	d.arcs = make(map[string][]Edge)
+	return &d
+}
+
+// adds a node to the directed graph
+func (d *Digraph) AddNode(node Node) error {
+	if _, ok := d.nodes[node.Id]; ok {
+		return nodeError(node.Id, ErrRepeatedNode)
+	}
+	d.nodes[node.Id] = node
+	return nil
+}
+
+// adds an arc going from arc.From to arc.To, the id is generated
+func (d *Digraph) AddArc(arc Edge) error {
+	if _, ok := d.nodes[arc.From.Id]; !ok {
+		return nodeError(arc.From.Id, ErrNodeNotPresent)
+	}
+	if _, ok := d.nodes[arc.To.Id]; !ok {
+		return nodeError(arc.To.Id, ErrNodeNotPresent)
+	}
+	arc.Id = d.nextId
+	d.nextId++
+	d.arcs[arc.From.Id] = append(d.arcs[arc.From.Id], arc)
+	return nil
+}
+
+// returns all nodes in ascending order by id
+func (d *Digraph) GetAllNodes() []Node {
+	nodes := make([]Node, 0, len(d.nodes))
+	for _, node := range d.nodes {
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, sortNodesById)
+	return nodes
+}
+
+// returns the arcs leaving node ordered by ascending weight
+func (d *Digraph) GetEdges(node Node) []Edge {
+	arcs := slices.Clone(d.arcs[node.Id])
+	slices.SortStableFunc(arcs, sortEdgesByWeight)
+	return arcs
+}
+
+// indicates if edges can only be followed from their from node, always for a digraph
+func (d *Digraph) IsDirected() bool {
+	return true
+}
+
+// returns a new directed graph with every arc pointing the other way
+func (d *Digraph) Reversed() *Digraph {
+	r := NewDigraph()
+	for _, node := range d.nodes {
+		r.AddNode(node)
+	}
+	for _, arcs := range d.arcs {
+		for _, arc := range arcs {
+			r.arcs[arc.To.Id] = append(r.arcs[arc.To.Id], arc.ReversedEdge())
+		}
+	}
+	r.nextId = d.nextId
+	return r
+}
@@ -4,59 +4,55 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"slices"
 )
 
 var (
-	ErrSelfEdge  = "cannot add edge between the same node"
-	ErrMaxIdUsed = "cannot generate a valid id for the edge, max id used"
+	ErrSelfEdge  = errors.New("cannot add edge between the same node")
+	ErrMaxIdUsed = errors.New("cannot generate a valid id for the edge, max id used")
 )
 
 type Edge struct {
-	Id     int  `json:"id"`
-	From   Node `json:"from"`
-	To     Node `json:"to"`
-	Weight int  `json:"weight"`
+	Id         int               `json:"id"`
+	From       Node              `json:"from"`
+	To         Node              `json:"to"`
+	Weight     int               `json:"weight"`
+	Name       string            `json:"name,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
-// generates a key for the edge
+// generates a key for the edge, it is the same for both directions
 func (e Edge) Key() string {
+	if e.From.Id > e.To.Id {
+		return fmt.Sprintf("[%v|%v|%v]", e.Id, e.To.Id, e.From.Id)
+	}
 	return fmt.Sprintf("[%v|%v|%v]", e.Id, e.From.Id, e.To.Id)
 }
 
 // returns a new edge with the from and to fields swapped
 func (e Edge) ReversedEdge() Edge {
-	return Edge{e.Id, e.To, e.From, e.Weight}
+	return Edge{Id: e.Id, From: e.To, To: e.From, Weight: e.Weight, Name: e.Name, Attributes: e.Attributes}
 }
 
 // retuns a new edge, the id is generated when adding it to the graph
 func NewEdge(from, to Node, weight int) Edge {
-	return Edge{0, from, to, weight}
+	return Edge{Id: 0, From: from, To: to, Weight: weight}
 }
 
 // returns all the edges present in the graph
-func (g *Graph) GetAllEdges() []Edge {
-	nodes := g.GetAllNodes()
-	edges := make([]Edge, 0)
-	for _, node := range nodes {
-		es := g.GetEdges(node)
-		for _, edge := range es {
-			edges = append(edges, edge)
-		}
+func (g Graph) GetAllEdges() []Edge {
+	a := g.adjacency()
+	edges := make([]Edge, 0, len(a.edges))
+	for _, node := range a.nodes {
+		edges = append(edges, a.edgesOf(node.Id)...)
 	}
 	return edges
 }
 
 // returns all edges that are reachable from node ordered by ascending weight
-func (g *Graph) GetEdges(node Node) []Edge {
-	edges := make([]Edge, 0)
-	edgesMap := g.Edges[node.Id]
-	for _, edgeSubMap := range edgesMap {
-		for _, edge := range edgeSubMap {
-			edges = append(edges, edge)
-		}
-	}
-	slices.SortFunc(edges, sortEdgesByWeight)
+func (g Graph) GetEdges(node Node) []Edge {
+	cached := g.adjacency().edgesOf(node.Id)
+	edges := make([]Edge, len(cached))
+	copy(edges, cached)
 	return edges
 }
 
@@ -71,7 +67,7 @@ var sortEdgesByWeight func(a, b Edge) int = func(a, b Edge) int {
 }
 
 // returns the shortest edge between the from and to nodes, indicates if it was found
-func (g *Graph) GetShortestEdge(from, to Node) (Edge, bool) {
+func (g Graph) GetShortestEdge(from, to Node) (Edge, bool) {
 	var zero Edge
 	if len(g.GetEdges(from)) == 0 {
 		return zero, false
@@ -93,20 +89,45 @@ func (g *Graph) GetShortestEdge(from, to Node) (Edge, bool) {
 
 // adds an edge to the graph
 func (g *Graph) AddEdge(edge Edge) error {
+	_, err := g.addEdge(edge)
+	return err
+}
+
+// adds an edge to the graph and returns it with the generated id
+func (g *Graph) addEdge(edge Edge) (Edge, error) {
+	g.init()
 	from := edge.From
 	to := edge.To
 
 	if from.Id == to.Id {
-		return errors.New(ErrSelfEdge)
+		return Edge{}, nodeError(from.Id, ErrSelfEdge)
 	}
 
 	if _, err := g.GetNode(from.Id); err != nil {
-		return errors.New(ErrNodeNotPresent)
+		return Edge{}, err
 	}
 	if _, err := g.GetNode(to.Id); err != nil {
-		return errors.New(ErrNodeNotPresent)
+		return Edge{}, err
 	}
 
+	// ids are unique in the whole graph and never reused
+	if g.edgeIndex.next == math.MaxInt {
+		return Edge{}, ErrMaxIdUsed
+	}
+	edge.Id = g.edgeIndex.next
+	g.insertEdge(edge)
+	return edge, nil
+}
+
+// stores the edge in both directions keeping its id, nodes must be present
+func (g *Graph) insertEdge(edge Edge) {
+	g.init()
+	// edges only reference their nodes, names and attributes live in g.Nodes
+	edge.From = Node{Id: edge.From.Id}
+	edge.To = Node{Id: edge.To.Id}
+	from := edge.From
+	to := edge.To
+
 	// create the map for the from node
 	if _, ok := g.Edges[from.Id]; !ok {
 		g.Edges[from.Id] = make(map[string]map[int]Edge)
@@ -125,39 +146,68 @@ func (g *Graph) AddEdge(edge Edge) error {
 		g.Edges[to.Id][from.Id] = make(map[int]Edge)
 	}
 
-	// find a valid id and add the edge
-	i := 0
-	for {
-		if i >= math.MaxInt {
-			return errors.New(ErrMaxIdUsed)
-		}
-		if _, okFrom := g.Edges[from.Id][to.Id][i]; !okFrom {
-			if _, okTo := g.Edges[to.Id][from.Id][i]; !okTo {
-				edge.Id = i
-				g.Edges[from.Id][to.Id][i] = edge
-				g.Edges[to.Id][from.Id][i] = edge.ReversedEdge()
-				break
-			}
-		}
-		i++
-	}
-
-	return nil
+	g.Edges[from.Id][to.Id][edge.Id] = edge
+	g.Edges[to.Id][from.Id][edge.Id] = edge.ReversedEdge()
+	g.edgeIndex.add(edge)
+	g.invalidate()
 }
 
 // Removes first edge found between from and to nodes with given weight value
 func (g *Graph) RemoveEdgeWithWeight(from, to Node, weight int) {
+	g.removeEdgeWithWeight(from, to, weight)
+}
+
+// removes first edge found between from and to nodes with given weight value, returns the removed edge
+func (g *Graph) removeEdgeWithWeight(from, to Node, weight int) (Edge, bool) {
+	g.init()
 	for id, edge := range g.Edges[from.Id][to.Id] {
 		if edge.Weight == weight {
 			delete(g.Edges[from.Id][to.Id], id)
 			delete(g.Edges[to.Id][from.Id], id)
-			return
+			delete(g.edgeIndex.ends, id)
+			g.invalidate()
+			return edge, true
 		}
 	}
+	return Edge{}, false
+}
+
+// removes the edge with the same id between its from and to nodes
+func (g *Graph) removeEdge(edge Edge) {
+	g.init()
+	delete(g.Edges[edge.From.Id][edge.To.Id], edge.Id)
+	delete(g.Edges[edge.To.Id][edge.From.Id], edge.Id)
+	delete(g.edgeIndex.ends, edge.Id)
+	g.invalidate()
 }
 
 // Removes all edges between from and to nodes
 func (g *Graph) RemoveEdges(from, to Node) {
+	g.init()
+	for id := range g.Edges[from.Id][to.Id] {
+		delete(g.edgeIndex.ends, id)
+	}
 	delete(g.Edges[from.Id], to.Id)
 	delete(g.Edges[to.Id], from.Id)
+	g.invalidate()
+}
+
+// returns the edge with given id in the direction it was added, indicates if it was found
+func (g Graph) GetEdgeById(id int) (Edge, bool) {
+	ends, ok := g.getEdgeIndex().ends[id]
+	if !ok {
+		return Edge{}, false
+	}
+	edge, ok := g.Edges[ends[0]][ends[1]][id]
+	return edge, ok
+}
+
+// removes the edge with given id, or returns an error if it does not exist
+func (g *Graph) RemoveEdgeById(id int) error {
+	edge, ok := g.GetEdgeById(id)
+	if !ok {
+		return &EdgeError{Id: id, Err: ErrEdgeNotPresent}
+	}
+	g.removeEdge(edge)
+	return nil
 }
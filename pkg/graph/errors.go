@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"fmt"
+)
+
+// returned when an operation fails because of a node, Err is one of the
+// sentinel errors of the package so it can be checked with errors.Is
+type NodeError struct {
+	Id  string
+	Err error
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err, e.Id)
+}
+
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
+
+// returned when an operation fails because of an edge, From and To are empty
+// when only the id of the edge is known
+type EdgeError struct {
+	Id   int
+	From string
+	To   string
+	Err  error
+}
+
+func (e *EdgeError) Error() string {
+	if e.From == "" && e.To == "" {
+		return fmt.Sprintf("%s: %d", e.Err, e.Id)
+	}
+	return fmt.Sprintf("%s: %s-%s[%d]", e.Err, e.From, e.To, e.Id)
+}
+
+func (e *EdgeError) Unwrap() error {
+	return e.Err
+}
+
+// returns an error for the node wrapping err
+func nodeError(id string, err error) error {
+	return &NodeError{Id: id, Err: err}
+}
+
+// returns an error for the edge wrapping err
+func edgeError(edge Edge, err error) error {
+	return &EdgeError{Id: edge.Id, From: edge.From.Id, To: edge.To.Id, Err: err}
+}
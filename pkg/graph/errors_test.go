@@ -0,0 +1,52 @@
+package graph_test
+
+import (
+	"errors"
+	"graph/pkg/graph"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	g := graph.NewGraph()
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	_ = g.AddNode(nodeA)
+
+	var nodeErr *graph.NodeError
+	_, err := g.GetNode("b")
+	if !errors.Is(err, graph.ErrNodeNotPresent) || !errors.As(err, &nodeErr) || nodeErr.Id != "b" {
+		t.Fatalf(`GetNode("b") error = %v, want a NodeError for b wrapping ErrNodeNotPresent`, err)
+	}
+	err = g.AddNode(nodeA)
+	if !errors.Is(err, graph.ErrRepeatedNode) || !errors.As(err, &nodeErr) || nodeErr.Id != "a" {
+		t.Fatalf("AddNode(a) error = %v, want a NodeError for a wrapping ErrRepeatedNode", err)
+	}
+	err = g.AddEdge(graph.NewEdge(nodeA, nodeB, 1))
+	if !errors.Is(err, graph.ErrNodeNotPresent) || !errors.As(err, &nodeErr) || nodeErr.Id != "b" {
+		t.Fatalf("AddEdge(a, b) error = %v, want a NodeError for b wrapping ErrNodeNotPresent", err)
+	}
+	err = g.AddEdge(graph.NewEdge(nodeA, nodeA, 1))
+	if !errors.Is(err, graph.ErrSelfEdge) {
+		t.Fatalf("AddEdge(a, a) error = %v, want ErrSelfEdge", err)
+	}
+
+	var edgeErr *graph.EdgeError
+	err = g.RemoveEdgeById(7)
+	if !errors.Is(err, graph.ErrEdgeNotPresent) || !errors.As(err, &edgeErr) || edgeErr.Id != 7 {
+		t.Fatalf("RemoveEdgeById(7) error = %v, want an EdgeError for 7 wrapping ErrEdgeNotPresent", err)
+	}
+
+	_, err = graph.NewNode(" ")
+	if !errors.Is(err, graph.ErrEmptyNodeId) {
+		t.Fatalf(`NewNode(" ") error = %v, want ErrEmptyNodeId`, err)
+	}
+	_, err = graph.NewNode("a b")
+	if !errors.Is(err, graph.ErrInvalidNodeId) {
+		t.Fatalf(`NewNode("a b") error = %v, want ErrInvalidNodeId`, err)
+	}
+
+	err = g.ApplyDiff(graph.Diff{RemovedNodes: []graph.Node{nodeB}})
+	if !errors.Is(err, graph.ErrPatchConflict) {
+		t.Fatalf("ApplyDiff() error = %v, want ErrPatchConflict", err)
+	}
+}
@@ -2,15 +2,18 @@ package graph
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
+	"maps"
 	"os"
-	"time"
+	"slices"
 )
 
 type Graph struct {
 	Nodes map[string]Node                    `json:"nodes"`
 	Edges map[string]map[string]map[int]Edge `json:"edges"`
+	// shared by copies of the graph, just like the maps
+	index          *nodeIndex
+	edgeIndex      *edgeIndex
+	adjacencyCache *adjacencyCache
 }
 
 // initializes an empty graph
@@ -18,24 +21,103 @@ func NewGraph() Graph {
 	g := Graph{}
 	g.Nodes = make(map[string]Node)
 	g.Edges = make(map[string]map[string]map[int]Edge)
+	g.index = newNodeIndex()
+	g.edgeIndex = newEdgeIndex()
+	g.adjacencyCache = &adjacencyCache{}
 	return g
 }
 
-// makes a copy of the graph
+// decodes the graph and builds the node and edge indices, files saved before
+// edge ids were unique in the whole graph get their edges renumbered
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	type plainGraph Graph
+	var pg plainGraph
+	err := json.Unmarshal(data, &pg)
+	if err != nil {
+		return err
+	}
+	*g = Graph(pg)
+	if g.Nodes == nil {
+		g.Nodes = make(map[string]Node)
+	}
+	if g.Edges == nil {
+		g.Edges = make(map[string]map[string]map[int]Edge)
+	}
+	g.index = newNodeIndexFrom(g.Nodes)
+	g.edgeIndex = newEdgeIndex()
+	g.adjacencyCache = &adjacencyCache{}
+
+	edges := make([]Edge, 0)
+	renumber := false
+	for _, neighbours := range g.Edges {
+		for _, pair := range neighbours {
+			for _, edge := range pair {
+				if edge.From.Id > edge.To.Id {
+					continue
+				}
+				if g.edgeIndex.usedByOther(edge) {
+					renumber = true
+				}
+				g.edgeIndex.add(edge)
+				edges = append(edges, edge)
+			}
+		}
+	}
+	if renumber {
+		// the first edge with each id keeps it and the ones repeating it get
+		// new ids above the highest, so most ids survive between loads
+		slices.SortFunc(edges, sortEdgesByKey)
+		next := g.edgeIndex.next
+		g.Edges = make(map[string]map[string]map[int]Edge)
+		g.edgeIndex = newEdgeIndex()
+		repeated := make([]Edge, 0)
+		for _, edge := range edges {
+			if _, ok := g.edgeIndex.ends[edge.Id]; ok {
+				repeated = append(repeated, edge)
+				continue
+			}
+			g.insertEdge(edge)
+		}
+		g.edgeIndex.next = next
+		for _, edge := range repeated {
+			edge.Id = g.edgeIndex.next
+			g.insertEdge(edge)
+		}
+	}
+	return nil
+}
+
+// makes a copy of the graph, nodes keep their indices and edges their ids
 func (g Graph) Clone() Graph {
 	clone := NewGraph()
+	maps.Copy(clone.Nodes, g.Nodes)
+	for from, neighbours := range g.Edges {
+		clone.Edges[from] = make(map[string]map[int]Edge, len(neighbours))
+		for to, pair := range neighbours {
+			clone.Edges[from][to] = maps.Clone(pair)
+		}
+	}
+	clone.index = g.getNodeIndex().clone()
+	clone.edgeIndex = g.getEdgeIndex().clone()
+	return clone
+}
+
+// returns a graph with every node of g and only the given edges of g, which
+// keep their ids, new edges get ids not used in g
+func Subgraph(g View, edges []Edge) (Graph, error) {
+	sub := NewGraph()
 	for _, node := range g.GetAllNodes() {
-		clone.AddNode(node)
+		sub.AddNode(node)
 	}
-	addedEdges := make(map[string]bool)
-	for _, edge := range g.GetAllEdges() {
-		if !addedEdges[edge.Key()] && !addedEdges[edge.ReversedEdge().Key()] {
-			clone.AddEdge(edge)
-			addedEdges[edge.Key()] = true
-			addedEdges[edge.ReversedEdge().Key()] = true
+	for _, edge := range edges {
+		found, ok := g.GetEdgeById(edge.Id)
+		if !ok || found.Key() != edge.Key() {
+			return Graph{}, edgeError(edge, ErrEdgeNotPresent)
 		}
+		sub.insertEdge(edge)
 	}
-	return clone
+	sub.edgeIndex.next = max(sub.edgeIndex.next, g.EdgeIdLimit())
+	return sub, nil
 }
 
 // initializes a graph from given file
@@ -52,35 +134,16 @@ func NewGraphFromFile(filename string) (Graph, error) {
 	return g, nil
 }
 
-// saves graph state to a file
-func (g *Graph) SaveGraphToFile() error {
-	bytes, err := json.Marshal(g)
+// saves graph state as a new snapshot in the default directory, returns the path used
+func (g *Graph) SaveGraphToFile() (string, error) {
+	snapshot, err := NewSnapshotStore(DefaultSnapshotDir).Save(*g)
 	if err != nil {
-		return err
-	}
-	err = os.Mkdir("snapshots", 0755)
-	if err != nil && !errors.Is(err, os.ErrExist) {
-		return err
+		return "", err
 	}
-	now := time.Now().Format("02-01-06 15:04:05")
-	filename := fmt.Sprintf("snapshots/%s.json", now)
-	err = os.WriteFile(filename, bytes, 0644)
-	if err != nil {
-		return err
-	}
-	fmt.Printf("Saved as %s\n", filename)
-	return nil
+	return snapshot.Path, nil
 }
 
-// prints all nodes and edges in the graph organized
+// prints all nodes and edges in the graph organized, with their names and attributes
 func (g *Graph) Print() {
-	nodes := g.GetAllNodes()
-	for _, node := range nodes {
-		fmt.Printf("%s: ", node.Id)
-		edges := g.GetEdges(node)
-		for _, edge := range edges {
-			fmt.Printf("%s[%d](%d) ", edge.To.Id, edge.Id, edge.Weight)
-		}
-		fmt.Println()
-	}
+	printView(*g)
 }
@@ -1,19 +1,39 @@
 package graph_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"graph/pkg/graph"
 	"testing"
 )
 
+// returns a graph with a node for each id, and the nodes by id
+func newGraphWithNodes(t *testing.T, ids ...string) (graph.Graph, map[string]graph.Node) {
+	t.Helper()
+	g := graph.NewGraph()
+	nodes := make(map[string]graph.Node)
+	for _, id := range ids {
+		node, err := graph.NewNode(id)
+		if err != nil {
+			t.Fatalf("NewNode(%v) failed: %v", id, err)
+		}
+		if err := g.AddNode(node); err != nil {
+			t.Fatalf("AddNode(%v) failed: %v", id, err)
+		}
+		nodes[id] = node
+	}
+	return g, nodes
+}
+
 func TestNewNode(t *testing.T) {
-	invalidIds := []string{"normal1", "thisisfine()", "...777", "_._asdfñ"}
+	invalidIds := []string{"thisisfine()", "a b", "", "   ", "x/y", "O'Higgins"}
 	for _, invalidId := range invalidIds {
 		_, err := graph.NewNode(invalidId)
 		if err == nil {
 			t.Fatalf(`NewNode("%v") should return an error, %s is invalid`, invalidId, invalidId)
 		}
 	}
-	validIds := []string{"ABC__jj", "jklIIO.aa._z", "a_b_c_d", "a.z.i.n"}
+	validIds := []string{"ABC__jj", "jklIIO.aa._z", "a_b_c_d", "a.z.i.n", "normal1", "...777", "_._asdfñ", "Ñuñoa", "calle-larga", "123456"}
 	for _, validId := range validIds {
 		_, err := graph.NewNode(validId)
 		if err != nil {
@@ -22,6 +42,113 @@ func TestNewNode(t *testing.T) {
 	}
 }
 
+func TestNodeIdValidator(t *testing.T) {
+	graph.SetNodeIdValidator(graph.ASCIINodeIdValidator)
+	t.Cleanup(func() { graph.SetNodeIdValidator(nil) })
+	invalidIds := []string{"normal1", "...777", "_._asdfñ", "calle-larga"}
+	for _, invalidId := range invalidIds {
+		_, err := graph.NewNode(invalidId)
+		if err == nil {
+			t.Fatalf(`NewNode("%v") should return an error with the ascii validator`, invalidId)
+		}
+	}
+	_, err := graph.NewNode("a_b.c")
+	if err != nil {
+		t.Fatalf(`NewNode("%v") should'nt return an error with the ascii validator`, "a_b.c")
+	}
+}
+
+func TestNodeIndex(t *testing.T) {
+	g := graph.NewGraph()
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	nodeC, _ := graph.NewNode("c")
+	_ = g.AddNode(nodeA)
+	_ = g.AddNode(nodeB)
+	_ = g.AddNode(nodeC)
+	indexC, _ := g.NodeIndex("c")
+
+	// removing a node keeps the indices of the others
+	g.RemoveNode(nodeB)
+	if _, ok := g.NodeIndex("b"); ok {
+		t.Fatalf(`NodeIndex("b") should fail, node b was removed`)
+	}
+	if i, _ := g.NodeIndex("c"); i != indexC {
+		t.Fatalf(`NodeIndex("c") = %v, want %v`, i, indexC)
+	}
+	node, ok := g.NodeAt(indexC)
+	if !ok || node.Id != "c" {
+		t.Fatalf("NodeAt(%v) = %v, %v, want node c", indexC, node, ok)
+	}
+
+	// files without indices load with one index per node
+	loaded, err := graph.NewGraphFromFile("../../graphs/euler.json")
+	if err != nil {
+		t.Fatalf("NewGraphFromFile() failed: %v", err)
+	}
+	seen := make(map[int]bool)
+	for _, node := range loaded.GetAllNodes() {
+		i, ok := loaded.NodeIndex(node.Id)
+		if !ok || seen[i] {
+			t.Fatalf("NodeIndex(%v) = %v, %v, want a unique index", node.Id, i, ok)
+		}
+		seen[i] = true
+	}
+}
+
+func TestLiteralGraph(t *testing.T) {
+	a := graph.Node{Id: "a"}
+	b := graph.Node{Id: "b"}
+	// graphs not created by NewGraph build their indices when needed
+	g := graph.Graph{
+		Nodes: map[string]graph.Node{"a": a, "b": b},
+		Edges: map[string]map[string]map[int]graph.Edge{
+			"a": {"b": {3: {Id: 3, From: a, To: b, Weight: 1}}},
+			"b": {"a": {3: {Id: 3, From: b, To: a, Weight: 1}}},
+		},
+	}
+	if i, ok := g.NodeIndex("b"); !ok || i != 1 {
+		t.Fatalf(`NodeIndex("b") = %v, %v, want %v`, i, ok, 1)
+	}
+	if node, ok := g.NodeAt(0); !ok || node.Id != "a" || g.NodeIndexLimit() != 2 {
+		t.Fatalf("NodeAt(0) = %v, %v, want node a of %v", node, ok, 2)
+	}
+	if edge, ok := g.GetEdgeById(3); !ok || edge.Weight != 1 {
+		t.Fatalf("GetEdgeById(3) = %v, %v, want the edge between a and b", edge, ok)
+	}
+	c, _ := graph.NewNode("c")
+	if err := g.AddNode(c); err != nil {
+		t.Fatalf("AddNode(c) failed: %v", err)
+	}
+	if err := g.AddEdge(graph.NewEdge(b, c, 2)); err != nil {
+		t.Fatalf("AddEdge(b, c) failed: %v", err)
+	}
+	if edge, ok := g.GetShortestEdge(b, c); !ok || edge.Id != 4 {
+		t.Fatalf("AddEdge(b, c) got id %v, want %v", edge.Id, 4)
+	}
+	if i, ok := g.NodeIndex("c"); !ok || i != 2 {
+		t.Fatalf(`NodeIndex("c") = %v, %v, want %v`, i, ok, 2)
+	}
+
+	var empty graph.Graph
+	if _, ok := empty.NodeIndex("a"); ok || empty.NodeIndexLimit() != 0 || empty.EdgeIdLimit() != 0 {
+		t.Fatalf("Graph{} has indices, want them empty")
+	}
+	if _, ok := empty.NodeAt(0); ok {
+		t.Fatalf("NodeAt(0) on Graph{} found a node")
+	}
+	if err := empty.AddNode(a); err != nil {
+		t.Fatalf("AddNode(a) on Graph{} failed: %v", err)
+	}
+	if i, ok := empty.NodeIndex("a"); !ok || i != 0 {
+		t.Fatalf(`NodeIndex("a") = %v, %v, want %v`, i, ok, 0)
+	}
+	empty.RemoveNode(a)
+	if len(empty.GetAllNodes()) != 0 {
+		t.Fatalf("RemoveNode(a) left %v", empty.GetAllNodes())
+	}
+}
+
 func TestAddNode(t *testing.T) {
 	g := graph.NewGraph()
 	nodeId := "a"
@@ -105,6 +232,103 @@ func TestRemoveEdge(t *testing.T) {
 	}
 }
 
+func TestEdgeIds(t *testing.T) {
+	g := graph.NewGraph()
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	nodeC, _ := graph.NewNode("c")
+	_ = g.AddNode(nodeA)
+	_ = g.AddNode(nodeB)
+	_ = g.AddNode(nodeC)
+	_ = g.AddEdge(graph.NewEdge(nodeA, nodeB, 1))
+	_ = g.AddEdge(graph.NewEdge(nodeB, nodeC, 2))
+	_ = g.AddEdge(graph.NewEdge(nodeC, nodeA, 3))
+
+	// ids are unique in the whole graph, not only between two nodes
+	for id, weight := range []int{1, 2, 3} {
+		edge, ok := g.GetEdgeById(id)
+		if !ok || edge.Weight != weight {
+			t.Fatalf("GetEdgeById(%v) = %v, %v, want the edge with weight %v", id, edge, ok, weight)
+		}
+		if edge.Key() != edge.ReversedEdge().Key() {
+			t.Fatalf("Key() of edge %v differs from the key of its reversed edge", id)
+		}
+	}
+
+	err := g.RemoveEdgeById(1)
+	if err != nil {
+		t.Fatalf("RemoveEdgeById(1) failed: %v", err)
+	}
+	if _, ok := g.GetEdgeById(1); ok || g.Degree(nodeB) != 1 {
+		t.Fatalf("RemoveEdgeById(1) didn't remove the edge between b and c")
+	}
+	if g.RemoveEdgeById(1) == nil {
+		t.Fatalf("RemoveEdgeById(1) should fail, the edge was already removed")
+	}
+
+	// removed ids are not reused
+	_ = g.AddEdge(graph.NewEdge(nodeB, nodeC, 2))
+	if edge, _ := g.GetShortestEdge(nodeB, nodeC); edge.Id != 3 {
+		t.Fatalf("AddEdge(b, c) got id %v, want %v", edge.Id, 3)
+	}
+
+	clone := g.Clone()
+	_ = clone.AddEdge(graph.NewEdge(nodeA, nodeB, 4))
+	if edge, _ := clone.GetEdgeById(0); edge.Weight != 1 {
+		t.Fatalf("Clone() changed the id of the edge between a and b")
+	}
+	if edge, ok := clone.GetEdgeById(4); !ok || edge.Weight != 4 {
+		t.Fatalf("AddEdge() on the clone got id %v, want %v", edge.Id, 4)
+	}
+
+	// files saved with ids repeated between different nodes get unique ids
+	loaded, err := graph.NewGraphFromFile("../../graphs/euler.json")
+	if err != nil {
+		t.Fatalf("NewGraphFromFile() failed: %v", err)
+	}
+	for _, edge := range loaded.GetAllEdges() {
+		if found, ok := loaded.GetEdgeById(edge.Id); !ok || found.Key() != edge.Key() {
+			t.Fatalf("GetEdgeById(%v) on the loaded graph = %v, want %v", edge.Id, found, edge)
+		}
+	}
+}
+
+func TestUnmarshalRepeatedEdgeIds(t *testing.T) {
+	// the edge between a and d repeats the id of the one between a and b
+	legacy := `{"nodes":{"a":{"id":"a"},"b":{"id":"b"},"c":{"id":"c"},"d":{"id":"d"}},"edges":{
+		"a":{"b":{"0":{"id":0,"from":{"id":"a"},"to":{"id":"b"},"weight":1}},"d":{"0":{"id":0,"from":{"id":"a"},"to":{"id":"d"},"weight":4}}%s},
+		"b":{"a":{"0":{"id":0,"from":{"id":"b"},"to":{"id":"a"},"weight":1}},"c":{"1":{"id":1,"from":{"id":"b"},"to":{"id":"c"},"weight":2}}},
+		"c":{"b":{"1":{"id":1,"from":{"id":"c"},"to":{"id":"b"},"weight":2}},"d":{"3":{"id":3,"from":{"id":"c"},"to":{"id":"d"},"weight":3}}%s},
+		"d":{"c":{"3":{"id":3,"from":{"id":"d"},"to":{"id":"c"},"weight":3}},"a":{"0":{"id":0,"from":{"id":"d"},"to":{"id":"a"},"weight":4}}}}}`
+	var old, current graph.Graph
+	err := json.Unmarshal([]byte(fmt.Sprintf(legacy, "", "")), &old)
+	if err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	// the same file with an edge between a and c
+	ac := `,"c":{"2":{"id":2,"from":{"id":"a"},"to":{"id":"c"},"weight":5}}`
+	ca := `,"a":{"2":{"id":2,"from":{"id":"c"},"to":{"id":"a"},"weight":5}}`
+	err = json.Unmarshal([]byte(fmt.Sprintf(legacy, ac, ca)), &current)
+	if err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+
+	// only the repeated id changes, the others are kept
+	for id, key := range map[int]string{0: "a-b", 1: "b-c", 3: "c-d", 4: "a-d"} {
+		if edge, ok := old.GetEdgeById(id); !ok || edge.From.Id+"-"+edge.To.Id != key {
+			t.Fatalf("GetEdgeById(%v) = %v, want the edge %v", id, edge, key)
+		}
+	}
+	d := graph.DiffGraphs(old, current)
+	if len(d.AddedEdges) != 1 || d.AddedEdges[0].Id != 2 {
+		t.Fatalf("DiffGraphs() added %v, want the edge between a and c", d.AddedEdges)
+	}
+	d.AddedEdges = d.AddedEdges[:0]
+	if !d.Empty() {
+		t.Fatalf("DiffGraphs() = %+v, want only the edge between a and c", d)
+	}
+}
+
 func TestGetEdge(t *testing.T) {
 	g := graph.NewGraph()
 	nodeA, _ := graph.NewNode("a")
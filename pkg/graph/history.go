@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// records the operations applied to a graph so they can be undone and redone
+type History struct {
+	graph  *Graph
+	done   []operation
+	undone []operation
+	// maximum number of operations that can be undone, zero or less keeps all of them
+	Limit int
+}
+
+type operation struct {
+	name string
+	do   func(g *Graph)
+	undo func(g *Graph)
+}
+
+// returns a new history that applies its operations to g
+func NewHistory(g *Graph) *History {
+	h := History{}
+	h.graph = g
+	h.done = make([]operation, 0)
+	h.undone = make([]operation, 0)
+	return &h
+}
+
+// returns the graph the history operates on
+func (h *History) Graph() *Graph {
+	return h.graph
+}
+
+// adds a node to the graph and records it
+func (h *History) AddNode(node Node) error {
+	err := h.graph.AddNode(node)
+	if err != nil {
+		return err
+	}
+	h.record(operation{
+		name: fmt.Sprintf("add node %s", node.Id),
+		do:   func(g *Graph) { g.AddNode(node) },
+		undo: func(g *Graph) { g.RemoveNode(node) },
+	})
+	return nil
+}
+
+// adds the nodes to the graph and records them as one operation, no node is
+// added if any of them is already present or repeated
+func (h *History) AddNodes(nodes []Node) error {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if _, err := h.graph.GetNode(node.Id); err == nil || slices.Contains(ids, node.Id) {
+			return nodeError(node.Id, ErrRepeatedNode)
+		}
+		ids = append(ids, node.Id)
+	}
+	add := func(g *Graph) {
+		for _, node := range nodes {
+			g.AddNode(node)
+		}
+	}
+	add(h.graph)
+	h.record(operation{
+		name: fmt.Sprintf("add nodes %s", strings.Join(ids, " ")),
+		do:   add,
+		undo: func(g *Graph) {
+			for _, node := range nodes {
+				g.RemoveNode(node)
+			}
+		},
+	})
+	return nil
+}
+
+// removes a node and all its edges from the graph and records it
+func (h *History) RemoveNode(node Node) {
+	node, err := h.graph.GetNode(node.Id)
+	if err != nil {
+		return
+	}
+	edges := h.graph.GetEdges(node)
+	h.graph.RemoveNode(node)
+	h.record(operation{
+		name: fmt.Sprintf("remove node %s", node.Id),
+		do:   func(g *Graph) { g.RemoveNode(node) },
+		undo: func(g *Graph) {
+			g.AddNode(node)
+			for _, edge := range edges {
+				g.insertEdge(edge)
+			}
+		},
+	})
+}
+
+// adds an edge to the graph and records it
+func (h *History) AddEdge(edge Edge) error {
+	edge, err := h.graph.addEdge(edge)
+	if err != nil {
+		return err
+	}
+	h.record(operation{
+		name: fmt.Sprintf("add edge %s", edge.Key()),
+		do:   func(g *Graph) { g.insertEdge(edge) },
+		undo: func(g *Graph) { g.removeEdge(edge) },
+	})
+	return nil
+}
+
+// removes all edges between from and to nodes and records it
+func (h *History) RemoveEdges(from, to Node) {
+	edges := make([]Edge, 0)
+	for _, edge := range h.graph.Edges[from.Id][to.Id] {
+		edges = append(edges, edge)
+	}
+	if len(edges) == 0 {
+		return
+	}
+	h.graph.RemoveEdges(from, to)
+	h.record(operation{
+		name: fmt.Sprintf("remove edges %s|%s", from.Id, to.Id),
+		do:   func(g *Graph) { g.RemoveEdges(from, to) },
+		undo: func(g *Graph) {
+			for _, edge := range edges {
+				g.insertEdge(edge)
+			}
+		},
+	})
+}
+
+// removes first edge found between from and to nodes with given weight value and records it
+func (h *History) RemoveEdgeWithWeight(from, to Node, weight int) {
+	edge, ok := h.graph.removeEdgeWithWeight(from, to, weight)
+	if !ok {
+		return
+	}
+	h.record(operation{
+		name: fmt.Sprintf("remove edge %s", edge.Key()),
+		do:   func(g *Graph) { g.removeEdge(edge) },
+		undo: func(g *Graph) { g.insertEdge(edge) },
+	})
+}
+
+// removes the edge with given id and records it
+func (h *History) RemoveEdgeById(id int) error {
+	edge, ok := h.graph.GetEdgeById(id)
+	if !ok {
+		return &EdgeError{Id: id, Err: ErrEdgeNotPresent}
+	}
+	h.graph.removeEdge(edge)
+	h.record(operation{
+		name: fmt.Sprintf("remove edge %s", edge.Key()),
+		do:   func(g *Graph) { g.removeEdge(edge) },
+		undo: func(g *Graph) { g.insertEdge(edge) },
+	})
+	return nil
+}
+
+// replaces the name and attributes of the node and records it
+func (h *History) UpdateNode(node Node) error {
+	old, err := h.graph.GetNode(node.Id)
+	if err != nil {
+		return err
+	}
+	err = h.graph.UpdateNode(node)
+	if err != nil {
+		return err
+	}
+	h.record(operation{
+		name: fmt.Sprintf("update node %s", node.Id),
+		do:   func(g *Graph) { g.UpdateNode(node) },
+		undo: func(g *Graph) { g.UpdateNode(old) },
+	})
+	return nil
+}
+
+// replaces the weight, name and attributes of the edge and records it
+func (h *History) UpdateEdge(edge Edge) error {
+	old, ok := h.graph.GetEdge(edge.From, edge.To, edge.Id)
+	if !ok {
+		return edgeError(edge, ErrEdgeNotPresent)
+	}
+	err := h.graph.UpdateEdge(edge)
+	if err != nil {
+		return err
+	}
+	h.record(operation{
+		name: fmt.Sprintf("update edge %s", edge.Key()),
+		do:   func(g *Graph) { g.UpdateEdge(edge) },
+		undo: func(g *Graph) { g.UpdateEdge(old) },
+	})
+	return nil
+}
+
+// applies the diff as a patch and records it
+func (h *History) ApplyDiff(d Diff) error {
+	err := h.graph.ApplyDiff(d)
+	if err != nil {
+		return err
+	}
+	inverse := d.Invert()
+	h.record(operation{
+		name: "apply patch",
+		do:   func(g *Graph) { g.ApplyDiff(d) },
+		undo: func(g *Graph) { g.ApplyDiff(inverse) },
+	})
+	return nil
+}
+
+// reverts the last operation, returns its description and if there was one
+func (h *History) Undo() (string, bool) {
+	if len(h.done) == 0 {
+		return "", false
+	}
+	op := h.done[len(h.done)-1]
+	h.done = h.done[:len(h.done)-1]
+	op.undo(h.graph)
+	h.undone = append(h.undone, op)
+	return op.name, true
+}
+
+// applies again the last undone operation, returns its description and if there was one
+func (h *History) Redo() (string, bool) {
+	if len(h.undone) == 0 {
+		return "", false
+	}
+	op := h.undone[len(h.undone)-1]
+	h.undone = h.undone[:len(h.undone)-1]
+	op.do(h.graph)
+	h.done = append(h.done, op)
+	return op.name, true
+}
+
+func (h *History) CanUndo() bool {
+	return len(h.done) > 0
+}
+
+func (h *History) CanRedo() bool {
+	return len(h.undone) > 0
+}
+
+// forgets all recorded operations, used when the graph is replaced
+func (h *History) Clear() {
+	h.done = make([]operation, 0)
+	h.undone = make([]operation, 0)
+}
+
+// stores a new operation, discarding the redo list and the oldest operations over the limit
+func (h *History) record(op operation) {
+	h.done = append(h.done, op)
+	h.undone = make([]operation, 0)
+	if h.Limit > 0 && len(h.done) > h.Limit {
+		h.done = slices.Delete(h.done, 0, len(h.done)-h.Limit)
+	}
+}
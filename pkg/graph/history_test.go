@@ -0,0 +1,100 @@
+package graph_test
+
+import (
+	"errors"
+	"graph/pkg/graph"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	g := graph.NewGraph()
+	h := graph.NewHistory(&g)
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	nodeC, _ := graph.NewNode("c")
+	_ = h.AddNode(nodeA)
+	_ = h.AddNode(nodeB)
+	_ = h.AddNode(nodeC)
+	_ = h.AddEdge(graph.NewEdge(nodeA, nodeB, 1))
+	_ = h.AddEdge(graph.NewEdge(nodeA, nodeB, 5))
+	_ = h.AddEdge(graph.NewEdge(nodeB, nodeC, 3))
+
+	h.RemoveEdges(nodeA, nodeB)
+	h.RemoveNode(nodeB)
+	if len(g.GetAllEdges()) != 0 {
+		t.Fatalf("GetAllEdges() should be empty after removing b, got %v", g.GetAllEdges())
+	}
+
+	// undo the node removal, only the b-c edge should come back
+	if _, ok := h.Undo(); !ok {
+		t.Fatalf("Undo() should revert the node removal")
+	}
+	if len(g.GetEdges(nodeB)) != 1 {
+		t.Fatalf("GetEdges(nodeB) should return %v edges, got %v", 1, len(g.GetEdges(nodeB)))
+	}
+
+	// undo the edges removal, both parallel edges should come back
+	h.Undo()
+	if len(g.GetEdges(nodeA)) != 2 {
+		t.Fatalf("GetEdges(nodeA) should return %v edges, got %v", 2, len(g.GetEdges(nodeA)))
+	}
+
+	// redo both removals
+	h.Redo()
+	h.Redo()
+	if _, err := g.GetNode(nodeB.Id); err == nil {
+		t.Fatalf(`GetNode("%v") should fail after redoing the node removal`, nodeB.Id)
+	}
+	if _, ok := h.Redo(); ok {
+		t.Fatalf("Redo() should return false, there is nothing left to redo")
+	}
+
+	// a new operation discards the redo list
+	h.Undo()
+	h.Undo()
+	h.RemoveEdgeWithWeight(nodeA, nodeB, 5)
+	if h.CanRedo() {
+		t.Fatalf("CanRedo() should be false after recording a new operation")
+	}
+	edges := g.GetEdges(nodeA)
+	if len(edges) != 1 || edges[0].Weight != 1 {
+		t.Fatalf("GetEdges(nodeA) = %v, want only the edge with weight %v", edges, 1)
+	}
+	h.Undo()
+	if len(g.GetEdges(nodeA)) != 2 {
+		t.Fatalf("GetEdges(nodeA) should return %v edges, got %v", 2, len(g.GetEdges(nodeA)))
+	}
+}
+
+func TestHistoryAddNodes(t *testing.T) {
+	g := graph.NewGraph()
+	h := graph.NewHistory(&g)
+	nodeA, _ := graph.NewNode("a")
+	nodeB, _ := graph.NewNode("b")
+	nodeC, _ := graph.NewNode("c")
+	_ = h.AddNode(nodeA)
+
+	// a node already present stops the whole batch
+	err := h.AddNodes([]graph.Node{nodeB, nodeA})
+	if !errors.Is(err, graph.ErrRepeatedNode) || len(g.GetAllNodes()) != 1 {
+		t.Fatalf("AddNodes(b, a) = %v and left %v nodes, want %v and %v", err, len(g.GetAllNodes()), graph.ErrRepeatedNode, 1)
+	}
+	err = h.AddNodes([]graph.Node{nodeB, nodeC, nodeB})
+	if !errors.Is(err, graph.ErrRepeatedNode) || len(g.GetAllNodes()) != 1 {
+		t.Fatalf("AddNodes(b, c, b) = %v and left %v nodes, want %v and %v", err, len(g.GetAllNodes()), graph.ErrRepeatedNode, 1)
+	}
+
+	// the batch is undone and redone at once
+	err = h.AddNodes([]graph.Node{nodeB, nodeC})
+	if err != nil {
+		t.Fatalf("AddNodes(b, c) failed: %v", err)
+	}
+	name, _ := h.Undo()
+	if name != "add nodes b c" || len(g.GetAllNodes()) != 1 {
+		t.Fatalf("Undo() = %q and left %v nodes, want the batch undone", name, len(g.GetAllNodes()))
+	}
+	h.Redo()
+	if len(g.GetAllNodes()) != 3 {
+		t.Fatalf("Redo() left %v nodes, want %v", len(g.GetAllNodes()), 3)
+	}
+}
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"maps"
+	"slices"
+)
+
+// assigns each node a stable integer index, indices of removed nodes are not reused
+type nodeIndex struct {
+	indices map[string]int
+	ids     []string
+}
+
+func newNodeIndex() *nodeIndex {
+	ni := nodeIndex{}
+	ni.indices = make(map[string]int)
+	ni.ids = make([]string, 0)
+	return &ni
+}
+
+// builds the index for the nodes of a graph in ascending order by id
+func newNodeIndexFrom(nodes map[string]Node) *nodeIndex {
+	ni := newNodeIndex()
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		ni.add(id)
+	}
+	return ni
+}
+
+func (ni *nodeIndex) clone() *nodeIndex {
+	return &nodeIndex{indices: maps.Clone(ni.indices), ids: slices.Clone(ni.ids)}
+}
+
+func (ni *nodeIndex) add(id string) {
+	if _, ok := ni.indices[id]; ok {
+		return
+	}
+	ni.indices[id] = len(ni.ids)
+	ni.ids = append(ni.ids, id)
+}
+
+func (ni *nodeIndex) remove(id string) {
+	i, ok := ni.indices[id]
+	if !ok {
+		return
+	}
+	delete(ni.indices, id)
+	ni.ids[i] = ""
+}
+
+// returns the node index, without one it is built again on every call in O(V)
+func (g Graph) getNodeIndex() *nodeIndex {
+	if g.index == nil {
+		return newNodeIndexFrom(g.Nodes)
+	}
+	return g.index
+}
+
+// returns the stable integer index of the node with given id, indicates if it was found
+func (g Graph) NodeIndex(id string) (int, bool) {
+	i, ok := g.getNodeIndex().indices[id]
+	return i, ok
+}
+
+// returns the node with given index, indicates if it was found
+func (g Graph) NodeAt(index int) (Node, bool) {
+	ni := g.getNodeIndex()
+	if index < 0 || index >= len(ni.ids) || ni.ids[index] == "" {
+		return Node{}, false
+	}
+	node, ok := g.Nodes[ni.ids[index]]
+	return node, ok
+}
+
+// returns an upper bound for node indices, useful to size slices indexed by node
+func (g Graph) NodeIndexLimit() int {
+	return len(g.getNodeIndex().ids)
+}
+
+// assigns graph wide unique edge ids and remembers the nodes of each edge, ids
+// of removed edges are not reused
+type edgeIndex struct {
+	next int
+	ends map[int][2]string
+}
+
+func newEdgeIndex() *edgeIndex {
+	ei := edgeIndex{}
+	ei.ends = make(map[int][2]string)
+	return &ei
+}
+
+// builds the index for the edges of a graph
+func newEdgeIndexFrom(edges map[string]map[string]map[int]Edge) *edgeIndex {
+	ei := newEdgeIndex()
+	for _, neighbours := range edges {
+		for _, pair := range neighbours {
+			for _, edge := range pair {
+				if edge.From.Id < edge.To.Id {
+					ei.add(edge)
+				}
+			}
+		}
+	}
+	return ei
+}
+
+// returns the edge index, without one it is built again on every call in O(E)
+func (g Graph) getEdgeIndex() *edgeIndex {
+	if g.edgeIndex == nil {
+		return newEdgeIndexFrom(g.Edges)
+	}
+	return g.edgeIndex
+}
+
+// builds what a graph not created by NewGraph or decoded from json is missing,
+// called by every method that changes the graph before it does
+func (g *Graph) init() {
+	if g.Nodes == nil {
+		g.Nodes = make(map[string]Node)
+	}
+	if g.Edges == nil {
+		g.Edges = make(map[string]map[string]map[int]Edge)
+	}
+	if g.index == nil {
+		g.index = newNodeIndexFrom(g.Nodes)
+	}
+	if g.edgeIndex == nil {
+		g.edgeIndex = newEdgeIndexFrom(g.Edges)
+	}
+	if g.adjacencyCache == nil {
+		g.adjacencyCache = &adjacencyCache{}
+	}
+}
+
+func (ei *edgeIndex) clone() *edgeIndex {
+	return &edgeIndex{next: ei.next, ends: maps.Clone(ei.ends)}
+}
+
+func (ei *edgeIndex) add(edge Edge) {
+	ei.ends[edge.Id] = [2]string{edge.From.Id, edge.To.Id}
+	if edge.Id >= ei.next {
+		ei.next = edge.Id + 1
+	}
+}
+
+// indicates if the id belongs to an edge between other nodes
+func (ei *edgeIndex) usedByOther(edge Edge) bool {
+	ends, ok := ei.ends[edge.Id]
+	if !ok {
+		return false
+	}
+	return ends != [2]string{edge.From.Id, edge.To.Id} && ends != [2]string{edge.To.Id, edge.From.Id}
+}
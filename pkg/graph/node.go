@@ -5,76 +5,93 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"unicode"
 )
 
 var (
-	ErrRepeatedNode   = "node is already in the graph"
-	ErrNodeNotPresent = "node is not present in the graph"
+	ErrRepeatedNode   = errors.New("node is already in the graph")
+	ErrNodeNotPresent = errors.New("node is not present in the graph")
+	ErrEmptyNodeId    = errors.New("node id cannot be empty")
+	ErrInvalidNodeId  = errors.New("invalid chars in node id")
 )
 
 type Node struct {
-	Id string `json:"id"`
+	Id         string            `json:"id"`
+	Name       string            `json:"name,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
-func (g *Graph) Degree(node Node) int {
-	edges := g.GetEdges(node)
-	return len(edges)
+func (g Graph) Degree(node Node) int {
+	return len(g.adjacency().edgesOf(node.Id))
 }
 
-// returns a new node, or an error if the id is invalid
-func NewNode(id string) (Node, error) {
-	copyId := id
-	id = strings.Trim(id, " ")
+// checks that a node id is valid, returns an error describing the problem otherwise
+type NodeIdValidator func(id string) error
+
+// accepts unicode letters and digits, '_', '.' and '-'
+var UnicodeNodeIdValidator NodeIdValidator = func(id string) error {
+	return checkIdChars(id, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+	})
+}
+
+// accepts only ascii letters, '_' and '.'
+var ASCIINodeIdValidator NodeIdValidator = func(id string) error {
+	return checkIdChars(id, func(r rune) bool {
+		return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || r == '_' || r == '.'
+	})
+}
+
+var nodeIdValidator = UnicodeNodeIdValidator
+
+// sets the validator used by NewNode, nil restores the default unicode validator
+func SetNodeIdValidator(v NodeIdValidator) {
+	if v == nil {
+		v = UnicodeNodeIdValidator
+	}
+	nodeIdValidator = v
+}
+
+// returns an error listing the chars in id that are not valid
+func checkIdChars(id string, valid func(r rune) bool) error {
 	invalidChars := make([]string, 0)
 	for _, r := range id {
-		if r == '_' || r == '.' {
-			continue
-		}
-		if ('a' > r || r > 'z') && ('A' > r || r > 'Z') {
-			if !slices.Contains(invalidChars, string(r)) {
-				invalidChars = append(invalidChars, string(r))
-			}
+		if !valid(r) && !slices.Contains(invalidChars, string(r)) {
+			invalidChars = append(invalidChars, string(r))
 		}
 	}
 	if len(invalidChars) != 0 {
-		var zero Node
-		return zero, fmt.Errorf("invalid chars in id %v: %v", copyId, invalidChars)
+		return fmt.Errorf("%w %v: %v", ErrInvalidNodeId, id, invalidChars)
+	}
+	return nil
+}
+
+// returns a new node, or an error if the id is invalid
+func NewNode(id string) (Node, error) {
+	id = strings.Trim(id, " ")
+	if id == "" {
+		return Node{}, ErrEmptyNodeId
+	}
+	err := nodeIdValidator(id)
+	if err != nil {
+		return Node{}, err
 	}
 	return Node{Id: id}, nil
 }
 
 // returns all nodes in the graph in ascending order by id
-func (g *Graph) GetAllNodes() []Node {
-	nodes := make([]Node, len(g.Nodes))
-	i := 0
-	for _, node := range g.Nodes {
-		nodes[i] = node
-		i++
-	}
-	slices.SortFunc(nodes, sortNodesById)
-	return nodes
+func (g Graph) GetAllNodes() []Node {
+	return slices.Clone(g.adjacency().nodes)
 }
 
 // returns all odd nodes in the graph in ascending order by id
-func (g *Graph) GetAllOddNodes() []Node {
-	oddNodes := make([]Node, 0)
-	for _, node := range g.GetAllNodes() {
-		if g.Degree(node)%2 == 1 {
-			oddNodes = append(oddNodes, node)
-		}
-	}
-	return oddNodes
+func (g Graph) GetAllOddNodes() []Node {
+	return oddNodes(g)
 }
 
 // returns all deadend nodes in the graph in ascending order by id
-func (g *Graph) GetAllDeadendNodes() []Node {
-	deadendNodes := make([]Node, 0)
-	for _, node := range g.GetAllNodes() {
-		if g.Degree(node) == 1 {
-			deadendNodes = append(deadendNodes, node)
-		}
-	}
-	return deadendNodes
+func (g Graph) GetAllDeadendNodes() []Node {
+	return deadendNodes(g)
 }
 
 var sortNodesById func(a, b Node) int = func(a, b Node) int {
@@ -86,37 +103,38 @@ var sortNodesById func(a, b Node) int = func(a, b Node) int {
 }
 
 // returns all nodes that are reachable from node
-func (g *Graph) GetNodes(node Node) []Node {
-	edges := g.GetEdges(node)
-	nodes := make([]Node, 0, len(edges))
-	for _, edge := range edges {
-		nodes = append(nodes, edge.To)
-	}
-	return nodes
+func (g Graph) GetNodes(node Node) []Node {
+	return neighbours(g, node)
 }
 
 // adds a node to the graph
 func (g *Graph) AddNode(node Node) error {
+	g.init()
 	if _, ok := g.Nodes[node.Id]; ok {
-		return errors.New(ErrRepeatedNode)
+		return nodeError(node.Id, ErrRepeatedNode)
 	}
 	g.Nodes[node.Id] = node
+	g.index.add(node.Id)
+	g.invalidate()
 	return nil
 }
 
 // gets node with given id from the graph, or an error if node does not exist
-func (g *Graph) GetNode(id string) (Node, error) {
+func (g Graph) GetNode(id string) (Node, error) {
 	if node, ok := g.Nodes[id]; ok {
 		return node, nil
 	}
-	return Node{}, errors.New(ErrNodeNotPresent)
+	return Node{}, nodeError(id, ErrNodeNotPresent)
 }
 
 // removes a node from the graph and all its edges
 func (g *Graph) RemoveNode(node Node) {
+	g.init()
 	edges := g.GetEdges(node)
 	for _, edge := range edges {
 		g.RemoveEdges(edge.From, edge.To)
 	}
 	delete(g.Nodes, node.Id)
+	g.index.remove(node.Id)
+	g.invalidate()
 }
@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"slices"
+)
+
+// view of a graph with extra edges on top. The base is never modified and
+// adding an edge does not copy it, so algorithms can extend a graph cheaply
+type Overlay struct {
+	base View
+	// added edges stored from both of their nodes
+	extra map[string][]Edge
+	// added edges in the direction they were added
+	byId   map[int]Edge
+	nextId int
+}
+
+// returns an overlay without extra edges on top of base
+func NewOverlay(base View) *Overlay {
+	o := Overlay{}
+	o.base = base
+	o.extra = make(map[string][]Edge)
+	o.byId = make(map[int]Edge)
+	o.nextId = base.EdgeIdLimit()
+	return &o
+}
+
+// returns the view below the overlay
+func (o *Overlay) Base() View {
+	return o.base
+}
+
+// adds an edge on top of the base, its id is generated after the ids of the base
+func (o *Overlay) AddEdge(edge Edge) error {
+	if edge.From.Id == edge.To.Id {
+		return nodeError(edge.From.Id, ErrSelfEdge)
+	}
+	if _, err := o.base.GetNode(edge.From.Id); err != nil {
+		return err
+	}
+	if _, err := o.base.GetNode(edge.To.Id); err != nil {
+		return err
+	}
+	edge.Id = o.nextId
+	o.nextId++
+	edge.From = Node{Id: edge.From.Id}
+	edge.To = Node{Id: edge.To.Id}
+	o.extra[edge.From.Id] = append(o.extra[edge.From.Id], edge)
+	o.extra[edge.To.Id] = append(o.extra[edge.To.Id], edge.ReversedEdge())
+	o.byId[edge.Id] = edge
+	return nil
+}
+
+// gets node with given id from the base, or an error if node does not exist
+func (o *Overlay) GetNode(id string) (Node, error) {
+	return o.base.GetNode(id)
+}
+
+// returns all nodes of the base in ascending order by id
+func (o *Overlay) GetAllNodes() []Node {
+	return o.base.GetAllNodes()
+}
+
+// returns all odd nodes counting the extra edges, in ascending order by id
+func (o *Overlay) GetAllOddNodes() []Node {
+	return oddNodes(o)
+}
+
+// returns all deadend nodes counting the extra edges, in ascending order by id
+func (o *Overlay) GetAllDeadendNodes() []Node {
+	return deadendNodes(o)
+}
+
+// returns all nodes that are reachable from node
+func (o *Overlay) GetNodes(node Node) []Node {
+	return neighbours(o, node)
+}
+
+func (o *Overlay) Degree(node Node) int {
+	return o.base.Degree(node) + len(o.extra[node.Id])
+}
+
+// returns the edges of the base and the extra edges of node ordered by ascending weight
+func (o *Overlay) GetEdges(node Node) []Edge {
+	edges := o.base.GetEdges(node)
+	extra := o.extra[node.Id]
+	if len(extra) == 0 {
+		return edges
+	}
+	edges = append(edges, extra...)
+	slices.SortFunc(edges, compareAdjacentEdges)
+	return edges
+}
+
+// returns all the edges of the base and the extra edges
+func (o *Overlay) GetAllEdges() []Edge {
+	edges := make([]Edge, 0)
+	for _, node := range o.GetAllNodes() {
+		edges = append(edges, o.GetEdges(node)...)
+	}
+	return edges
+}
+
+// returns the shortest edge between the from and to nodes, indicates if it was found
+func (o *Overlay) GetShortestEdge(from, to Node) (Edge, bool) {
+	for _, edge := range o.GetEdges(from) {
+		if edge.To.Id == to.Id {
+			return edge, true
+		}
+	}
+	return Edge{}, false
+}
+
+// returns the edge with given id in the direction it was added, indicates if it was found
+func (o *Overlay) GetEdgeById(id int) (Edge, bool) {
+	if edge, ok := o.byId[id]; ok {
+		return edge, true
+	}
+	return o.base.GetEdgeById(id)
+}
+
+// returns an upper bound for edge ids, including the extra edges
+func (o *Overlay) EdgeIdLimit() int {
+	return o.nextId
+}
+
+// indicates if edges can only be followed from their from node, as in the base graph
+func (o *Overlay) IsDirected() bool {
+	return o.base.IsDirected()
+}
+
+// returns the connected components counting the extra edges
+func (o *Overlay) ConnectedComponents() [][]Node {
+	return connectedComponents(o)
+}
+
+// returns the component number of each node, numbered as in ConnectedComponents
+func (o *Overlay) ComponentLabels() map[string]int {
+	return componentLabels(o)
+}
+
+// prints all nodes and edges, including the extra edges
+func (o *Overlay) Print() {
+	printView(o)
+}
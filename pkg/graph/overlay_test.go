@@ -0,0 +1,64 @@
+package graph_test
+
+import (
+	"graph/pkg/graph"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 3))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 2))
+
+	o := graph.NewOverlay(g)
+	err := o.AddEdge(graph.NewEdge(nodes["b"], nodes["a"], 1))
+	if err != nil {
+		t.Fatalf("AddEdge(b, a) failed: %v", err)
+	}
+	if o.AddEdge(graph.NewEdge(nodes["a"], nodes["a"], 1)) == nil {
+		t.Fatalf("AddEdge(a, a) should fail: self edge not allowed")
+	}
+	if o.AddEdge(graph.NewEdge(nodes["a"], graph.Node{Id: "x"}, 1)) == nil {
+		t.Fatalf("AddEdge(a, x) should fail: node x does not exist")
+	}
+
+	// the base is not modified
+	if len(g.GetEdges(nodes["a"])) != 1 || len(g.GetAllEdges()) != 4 {
+		t.Fatalf("AddEdge() on the overlay modified the base graph")
+	}
+
+	// extra edges are seen from both of their nodes, sorted with the others
+	edges := o.GetEdges(nodes["a"])
+	if len(edges) != 2 || edges[0].Weight != 1 || edges[0].To.Id != "b" {
+		t.Fatalf("GetEdges(a) = %v, want the extra edge first", edges)
+	}
+	if o.Degree(nodes["b"]) != 3 || len(o.GetAllEdges()) != 6 {
+		t.Fatalf("Degree(b) = %v, want %v", o.Degree(nodes["b"]), 3)
+	}
+	odd := o.GetAllOddNodes()
+	if len(odd) != 2 || odd[0].Id != "b" || odd[1].Id != "c" {
+		t.Fatalf("GetAllOddNodes() = %v, want b and c", odd)
+	}
+	if edge, _ := o.GetShortestEdge(nodes["a"], nodes["b"]); edge.Weight != 1 {
+		t.Fatalf("GetShortestEdge(a, b) weight = %v, want %v", edge.Weight, 1)
+	}
+
+	// extra edge ids come after the ids of the base
+	edge, ok := o.GetEdgeById(g.EdgeIdLimit())
+	if !ok || edge.From.Id != "b" || edge.To.Id != "a" {
+		t.Fatalf("GetEdgeById(%v) = %v, %v, want the extra edge", g.EdgeIdLimit(), edge, ok)
+	}
+	if edge, ok := o.GetEdgeById(0); !ok || edge.Weight != 3 {
+		t.Fatalf("GetEdgeById(0) = %v, %v, want the edge of the base", edge, ok)
+	}
+	if o.EdgeIdLimit() != g.EdgeIdLimit()+1 {
+		t.Fatalf("EdgeIdLimit() = %v, want %v", o.EdgeIdLimit(), g.EdgeIdLimit()+1)
+	}
+
+	// overlays can be stacked
+	top := graph.NewOverlay(o)
+	_ = top.AddEdge(graph.NewEdge(nodes["c"], nodes["d"], 4))
+	if len(top.ConnectedComponents()) != 1 || len(o.ConnectedComponents()) != 2 {
+		t.Fatalf("ConnectedComponents() of the stacked overlay should include the edge c-d")
+	}
+}
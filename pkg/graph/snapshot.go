@@ -0,0 +1,250 @@
+package graph
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoSnapshots      = errors.New("there are no snapshots in the store")
+	ErrSnapshotNotFound = errors.New("snapshot not found in the store")
+)
+
+const (
+	// name used to load the most recent snapshot
+	LatestSnapshot = "latest"
+	// default time layout for snapshot names, sortable and without spaces or colons
+	DefaultSnapshotLayout = "2006-01-02_15-04-05.000"
+	// default directory where snapshots are stored
+	DefaultSnapshotDir = "snapshots"
+)
+
+type Snapshot struct {
+	Name    string
+	Path    string
+	ModTime time.Time
+	// time in the name and number added to names taken within the same
+	// layout resolution, snapshots are ordered by them
+	taken time.Time
+	seq   int
+}
+
+// stores graph snapshots as json files inside a directory
+type SnapshotStore struct {
+	// directory where the snapshots are saved
+	Dir string
+	// time layout used to name new snapshots
+	Layout string
+	// maximum number of snapshots to keep, zero or less keeps all of them
+	Limit int
+}
+
+// returns a new snapshot store in dir using the default layout and no limit
+func NewSnapshotStore(dir string) SnapshotStore {
+	return SnapshotStore{Dir: dir, Layout: DefaultSnapshotLayout}
+}
+
+// saves the graph as a new snapshot and removes the oldest ones over the limit
+func (ss SnapshotStore) Save(g Graph) (Snapshot, error) {
+	err := os.MkdirAll(ss.Dir, 0755)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	now := time.Now()
+	name := now.Format(ss.Layout)
+	path := filepath.Join(ss.Dir, name+".json")
+	// avoid overwriting a snapshot taken within the same layout resolution
+	for i := 1; fileExists(path); i++ {
+		name = fmt.Sprintf("%s_%d", now.Format(ss.Layout), i)
+		path = filepath.Join(ss.Dir, name+".json")
+	}
+	err = g.SaveGraphToPath(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	err = ss.prune()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot, _ := ss.parseName(name)
+	snapshot.Path = path
+	snapshot.ModTime = info.ModTime()
+	return snapshot, nil
+}
+
+// returns the snapshot with the time and number in the name, indicates if the
+// name was given by the store, as the layout optionally followed by _<number>
+func (ss SnapshotStore) parseName(name string) (Snapshot, bool) {
+	snapshot := Snapshot{Name: name}
+	taken, err := time.Parse(ss.Layout, name)
+	if err == nil {
+		snapshot.taken = taken
+		return snapshot, true
+	}
+	i := strings.LastIndex(name, "_")
+	if i < 0 {
+		return snapshot, false
+	}
+	seq, err := strconv.Atoi(name[i+1:])
+	if err != nil || seq < 1 || name[i+1] == '+' {
+		return snapshot, false
+	}
+	taken, err = time.Parse(ss.Layout, name[:i])
+	if err != nil {
+		return snapshot, false
+	}
+	snapshot.taken = taken
+	snapshot.seq = seq
+	return snapshot, true
+}
+
+// returns all snapshots in the store ordered from oldest to newest, other
+// files in the directory are ignored, so they are never pruned
+func (ss SnapshotStore) List() ([]Snapshot, error) {
+	entries, err := os.ReadDir(ss.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Snapshot{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		snapshot, ok := ss.parseName(strings.TrimSuffix(entry.Name(), ".json"))
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Path = filepath.Join(ss.Dir, entry.Name())
+		snapshot.ModTime = info.ModTime()
+		snapshots = append(snapshots, snapshot)
+	}
+	slices.SortFunc(snapshots, sortSnapshotsByTime)
+	return snapshots, nil
+}
+
+// returns the most recent snapshot in the store
+func (ss SnapshotStore) Latest() (Snapshot, error) {
+	snapshots, err := ss.List()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if len(snapshots) == 0 {
+		return Snapshot{}, ErrNoSnapshots
+	}
+	return snapshots[len(snapshots)-1], nil
+}
+
+// returns the snapshot with the given name, or the most recent one if name is "latest"
+func (ss SnapshotStore) Get(name string) (Snapshot, error) {
+	if name == LatestSnapshot {
+		return ss.Latest()
+	}
+	snapshots, err := ss.List()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	name = strings.TrimSuffix(name, ".json")
+	for _, snapshot := range snapshots {
+		if snapshot.Name == name {
+			return snapshot, nil
+		}
+	}
+	return Snapshot{}, fmt.Errorf("%w: %s", ErrSnapshotNotFound, name)
+}
+
+// loads the graph stored in the snapshot with the given name, or the most recent one if name is "latest"
+func (ss SnapshotStore) Load(name string) (Graph, error) {
+	snapshot, err := ss.Get(name)
+	if err != nil {
+		return Graph{}, err
+	}
+	return NewGraphFromFile(snapshot.Path)
+}
+
+// removes the oldest snapshots until the store respects its limit
+func (ss SnapshotStore) prune() error {
+	if ss.Limit <= 0 {
+		return nil
+	}
+	snapshots, err := ss.List()
+	if err != nil {
+		return err
+	}
+	for len(snapshots) > ss.Limit {
+		err = os.Remove(snapshots[0].Path)
+		if err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// orders snapshots by the time in their names, the modification time changes
+// when files are copied or touched
+var sortSnapshotsByTime func(a, b Snapshot) int = func(a, b Snapshot) int {
+	return cmp.Or(a.taken.Compare(b.taken), cmp.Compare(a.seq, b.seq))
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// saves graph state to path, the file is replaced atomically
+func (g *Graph) SaveGraphToPath(path string) error {
+	bytes, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, bytes)
+}
+
+// writes data to a temporary file next to path and renames it to path
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	// cleans up the temporary file on failure, after the rename it no longer exists
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,98 @@
+package graph_test
+
+import (
+	"graph/pkg/graph"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshotStore(t *testing.T) {
+	ss := graph.NewSnapshotStore(t.TempDir())
+	ss.Limit = 2
+
+	_, err := ss.Load(graph.LatestSnapshot)
+	if err == nil {
+		t.Fatalf(`Load("%v") should fail on an empty store`, graph.LatestSnapshot)
+	}
+
+	g := graph.NewGraph()
+	names := make([]string, 0)
+	for _, id := range []string{"a", "b", "c"} {
+		node, _ := graph.NewNode(id)
+		_ = g.AddNode(node)
+		snapshot, err := ss.Save(g)
+		if err != nil {
+			t.Fatalf("Save(g) failed: %v", err)
+		}
+		names = append(names, snapshot.Name)
+	}
+
+	snapshots, err := ss.List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(snapshots) != ss.Limit {
+		t.Fatalf("List() returned %v snapshots, want %v", len(snapshots), ss.Limit)
+	}
+	if snapshots[0].Name != names[1] || snapshots[1].Name != names[2] {
+		t.Fatalf("List() = %v, want the two newest snapshots %v", snapshots, names[1:])
+	}
+
+	latest, err := ss.Load(graph.LatestSnapshot)
+	if err != nil {
+		t.Fatalf(`Load("%v") failed: %v`, graph.LatestSnapshot, err)
+	}
+	if len(latest.GetAllNodes()) != 3 {
+		t.Fatalf(`Load("%v") returned %v nodes, want %v`, graph.LatestSnapshot, len(latest.GetAllNodes()), 3)
+	}
+
+	named, err := ss.Load(names[1])
+	if err != nil {
+		t.Fatalf(`Load("%v") failed: %v`, names[1], err)
+	}
+	if len(named.GetAllNodes()) != 2 {
+		t.Fatalf(`Load("%v") returned %v nodes, want %v`, names[1], len(named.GetAllNodes()), 2)
+	}
+
+	_, err = ss.Load(names[0])
+	if err == nil {
+		t.Fatalf(`Load("%v") should fail, snapshot was removed by the limit`, names[0])
+	}
+}
+
+func TestSnapshotStoreOtherFiles(t *testing.T) {
+	ss := graph.NewSnapshotStore(t.TempDir())
+	ss.Limit = 1
+
+	// files not named by the store are never listed or pruned
+	fixture := filepath.Join(ss.Dir, "sb.json")
+	_ = os.WriteFile(fixture, []byte("{}"), 0644)
+	// snapshots are ordered by their names even if they were touched later
+	older := filepath.Join(ss.Dir, "2001-01-01_00-00-00.000_2.json")
+	newer := filepath.Join(ss.Dir, "2001-01-01_00-00-00.000_10.json")
+	_ = os.WriteFile(older, []byte("{}"), 0644)
+	_ = os.WriteFile(newer, []byte("{}"), 0644)
+	_ = os.Chtimes(newer, time.Now(), time.Now().Add(-time.Hour))
+
+	snapshots, err := ss.List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Path != older || snapshots[1].Path != newer {
+		t.Fatalf("List() = %v, want %v and then %v", snapshots, older, newer)
+	}
+
+	saved, err := ss.Save(graph.NewGraph())
+	if err != nil {
+		t.Fatalf("Save(g) failed: %v", err)
+	}
+	snapshots, _ = ss.List()
+	if len(snapshots) != 1 || snapshots[0].Name != saved.Name {
+		t.Fatalf("List() after Save(g) = %v, want only %v", snapshots, saved.Name)
+	}
+	if _, err := os.Stat(fixture); err != nil {
+		t.Fatalf("Save(g) removed %v: %v", fixture, err)
+	}
+}
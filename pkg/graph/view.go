@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"fmt"
+)
+
+// read only access to a graph, implemented by Graph and Overlay. Algorithms
+// accept it so they can run on either without copying anything
+type View interface {
+	GetNode(id string) (Node, error)
+	GetAllNodes() []Node
+	GetAllOddNodes() []Node
+	GetAllDeadendNodes() []Node
+	GetNodes(node Node) []Node
+	Degree(node Node) int
+	GetEdges(node Node) []Edge
+	GetAllEdges() []Edge
+	GetShortestEdge(from, to Node) (Edge, bool)
+	GetEdgeById(id int) (Edge, bool)
+	EdgeIdLimit() int
+	ConnectedComponents() [][]Node
+	ComponentLabels() map[string]int
+	IsDirected() bool
+}
+
+var (
+	_ View = Graph{}
+	_ View = (*Overlay)(nil)
+)
+
+// returns all odd nodes in ascending order by id
+func oddNodes(v View) []Node {
+	nodes := make([]Node, 0)
+	for _, node := range v.GetAllNodes() {
+		if v.Degree(node)%2 == 1 {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// returns all deadend nodes in ascending order by id
+func deadendNodes(v View) []Node {
+	nodes := make([]Node, 0)
+	for _, node := range v.GetAllNodes() {
+		if v.Degree(node) == 1 {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// returns all nodes that are reachable from node through one edge
+func neighbours(v View, node Node) []Node {
+	edges := v.GetEdges(node)
+	nodes := make([]Node, 0, len(edges))
+	for _, edge := range edges {
+		nodes = append(nodes, edge.To)
+	}
+	return nodes
+}
+
+// prints all nodes and edges organized, with their names and attributes
+func printView(v View) {
+	for _, node := range v.GetAllNodes() {
+		fmt.Printf("%s%s: ", node.Id, formatMetadata(node.Name, node.Attributes))
+		for _, edge := range v.GetEdges(node) {
+			fmt.Printf("%s[%d](%d)%s ", edge.To.Id, edge.Id, edge.Weight, formatMetadata(edge.Name, edge.Attributes))
+		}
+		fmt.Println()
+	}
+}
+
+// returns an upper bound for edge ids, useful to add edges on top of the graph
+func (g Graph) EdgeIdLimit() int {
+	return g.getEdgeIndex().next
+}
+
+// indicates if edges can only be followed from their from node, never for a graph
+func (g Graph) IsDirected() bool {
+	return false
+}
@@ -2,16 +2,20 @@ package menus
 
 import (
 	"fmt"
+	"graph/pkg/analysis"
 	"graph/pkg/graph"
+	"graph/pkg/tui"
 
 	"github.com/pinguin-frosch/menu/pkg/menu"
 )
 
 var GraphMenu *menu.Menu
 var Graph graph.Graph
+var History *graph.History
 
 func init() {
 	Graph = graph.NewGraph()
+	History = graph.NewHistory(&Graph)
 	GraphMenu = menu.NewMenu("graph")
 	GraphMenu.AddOption("s", "manage graph state", func() {
 		StateMenu.Start()
@@ -23,7 +27,7 @@ func init() {
 			fmt.Printf("error: %s\n", err.Error())
 			return
 		}
-		err = Graph.AddNode(node)
+		err = History.AddNode(node)
 		if err != nil {
 			fmt.Printf("error: %s\n", err.Error())
 			return
@@ -36,7 +40,7 @@ func init() {
 			fmt.Printf("error: %s\n", err.Error())
 			return
 		}
-		Graph.RemoveNode(node)
+		History.RemoveNode(node)
 	})
 	GraphMenu.AddOption("e", "add edge", func() {
 		fromId := GraphMenu.GetString("from: ")
@@ -57,7 +61,7 @@ func init() {
 			return
 		}
 		edge := graph.NewEdge(fromNode, toNode, weight)
-		err = Graph.AddEdge(edge)
+		err = History.AddEdge(edge)
 		if err != nil {
 			fmt.Printf("error: %s\n", err.Error())
 			return
@@ -76,7 +80,7 @@ func init() {
 			fmt.Printf("error: %s\n", err.Error())
 			return
 		}
-		Graph.RemoveEdges(fromNode, toNode)
+		History.RemoveEdges(fromNode, toNode)
 	})
 	GraphMenu.AddOption("erw", "remove edge with weight", func() {
 		fromId := GraphMenu.GetString("from: ")
@@ -96,7 +100,159 @@ func init() {
 			fmt.Printf("error: %s\n", err.Error())
 			return
 		}
-		Graph.RemoveEdgeWithWeight(fromNode, toNode, weight)
+		History.RemoveEdgeWithWeight(fromNode, toNode, weight)
+	})
+	GraphMenu.AddOption("eri", "remove edge by id", func() {
+		id, err := GraphMenu.GetInt("edge id: ")
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		err = History.RemoveEdgeById(id)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+	})
+	GraphMenu.AddOption("u", "undo last change", func() {
+		name, ok := History.Undo()
+		if !ok {
+			fmt.Println("nothing to undo")
+			return
+		}
+		fmt.Printf("undone: %s\n", name)
+	})
+	GraphMenu.AddOption("r", "redo last undone change", func() {
+		name, ok := History.Redo()
+		if !ok {
+			fmt.Println("nothing to redo")
+			return
+		}
+		fmt.Printf("redone: %s\n", name)
+	})
+	GraphMenu.AddOption("nn", "set node name", func() {
+		id := GraphMenu.GetString("id: ")
+		node, err := Graph.GetNode(id)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		node.Name = GraphMenu.GetString("name: ")
+		err = History.UpdateNode(node)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+	})
+	GraphMenu.AddOption("na", "set node attribute (empty value removes it)", func() {
+		id := GraphMenu.GetString("id: ")
+		node, err := Graph.GetNode(id)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		key := GraphMenu.GetString("key: ")
+		value := GraphMenu.GetString("value: ")
+		if value == "" {
+			node.RemoveAttribute(key)
+		} else {
+			node.SetAttribute(key, value)
+		}
+		err = History.UpdateNode(node)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+	})
+	GraphMenu.AddOption("en", "set edge name", func() {
+		edge, err := getEdge()
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		edge.Name = GraphMenu.GetString("name: ")
+		err = History.UpdateEdge(edge)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+	})
+	GraphMenu.AddOption("ea", "set edge attribute (empty value removes it)", func() {
+		edge, err := getEdge()
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		key := GraphMenu.GetString("key: ")
+		value := GraphMenu.GetString("value: ")
+		if value == "" {
+			edge.RemoveAttribute(key)
+		} else {
+			edge.SetAttribute(key, value)
+		}
+		err = History.UpdateEdge(edge)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+	})
+	GraphMenu.AddOption("q", "query nodes and edges by attribute", func() {
+		key := GraphMenu.GetString("key: ")
+		value := GraphMenu.GetString("value (empty matches any): ")
+		for _, node := range Graph.GetNodesWithAttribute(key, value) {
+			fmt.Printf("node %s (%s): %s\n", node.Id, node.Label(), node.Attributes[key])
+		}
+		for _, edge := range Graph.GetEdgesWithAttribute(key, value) {
+			fmt.Printf("edge %s-%s[%d] (%s): %s\n", edge.From.Id, edge.To.Id, edge.Id, edge.Name, edge.Attributes[key])
+		}
+	})
+	GraphMenu.AddOption("c", "print connected components", func() {
+		for i, component := range Graph.ConnectedComponents() {
+			fmt.Printf("%d:", i)
+			for _, node := range component {
+				fmt.Printf(" %s", node.Id)
+			}
+			fmt.Println()
+		}
+	})
+	GraphMenu.AddOption("b", "print bridges, articulation points and biconnected components", func() {
+		fmt.Print("bridges:")
+		for _, edge := range analysis.Bridges(Graph) {
+			fmt.Printf(" %s-%s[%d]", edge.From.Id, edge.To.Id, edge.Id)
+		}
+		fmt.Print("\narticulation points:")
+		for _, node := range analysis.ArticulationPoints(Graph) {
+			fmt.Printf(" %s", node.Id)
+		}
+		fmt.Println("\nbiconnected components:")
+		for i, component := range analysis.BiconnectedComponents(Graph) {
+			fmt.Printf("%d:", i)
+			for _, edge := range component {
+				fmt.Printf(" %s-%s[%d]", edge.From.Id, edge.To.Id, edge.Id)
+			}
+			fmt.Println()
+		}
+	})
+	GraphMenu.AddOption("st", "print graph stats", func() {
+		stats, err := analysis.GetStats(Graph)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		stats.Print()
+	})
+	GraphMenu.AddOption("sj", "print graph stats as json", func() {
+		stats, err := analysis.GetStats(Graph)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		bytes, err := stats.JSON()
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		fmt.Println(string(bytes))
 	})
 	GraphMenu.AddOption("p", "print graph", func() {
 		Graph.Print()
@@ -104,4 +260,24 @@ func init() {
 	GraphMenu.AddOption("t", "graph traverse sub menu", func() {
 		TraverseMenu.Start()
 	})
+	GraphMenu.AddOption("tui", "full screen graph editor", func() {
+		err := tui.Run(History)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+	})
+}
+
+// asks for the id of an edge and returns it
+func getEdge() (graph.Edge, error) {
+	id, err := GraphMenu.GetInt("edge id: ")
+	if err != nil {
+		return graph.Edge{}, err
+	}
+	edge, ok := Graph.GetEdgeById(id)
+	if !ok {
+		return graph.Edge{}, &graph.EdgeError{Id: id, Err: graph.ErrEdgeNotPresent}
+	}
+	return edge, nil
 }
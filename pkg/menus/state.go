@@ -2,17 +2,21 @@ package menus
 
 import (
 	"fmt"
+	"graph/pkg/generate"
 	"graph/pkg/graph"
 
 	"github.com/pinguin-frosch/menu/pkg/menu"
 )
 
 var StateMenu *menu.Menu
+var Snapshots graph.SnapshotStore
 
 func init() {
+	Snapshots = graph.NewSnapshotStore(graph.DefaultSnapshotDir)
 	StateMenu = menu.NewMenu("state")
 	StateMenu.AddOption("n", "create new graph", func() {
 		Graph = graph.NewGraph()
+		History.Clear()
 	})
 	StateMenu.AddOption("f", "new graph from file", func() {
 		path := StateMenu.GetString("path: ")
@@ -22,12 +26,183 @@ func init() {
 			return
 		}
 		Graph = g
+		History.Clear()
 	})
-	StateMenu.AddOption("s", "save graph state", func() {
-		err := Graph.SaveGraphToFile()
+	StateMenu.AddOption("g", "generate graph", func() {
+		g, err := generateGraph()
 		if err != nil {
 			fmt.Printf("error: %s\n", err.Error())
 			return
 		}
+		Graph = g
+		History.Clear()
+	})
+	StateMenu.AddOption("s", "save graph snapshot", func() {
+		snapshot, err := Snapshots.Save(Graph)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		fmt.Printf("Saved as %s\n", snapshot.Path)
+	})
+	StateMenu.AddOption("sp", "save graph as path", func() {
+		path := StateMenu.GetString("path: ")
+		err := Graph.SaveGraphToPath(path)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		fmt.Printf("Saved as %s\n", path)
+	})
+	StateMenu.AddOption("l", "list snapshots", func() {
+		snapshots, err := Snapshots.List()
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		for _, snapshot := range snapshots {
+			fmt.Printf("%s\n", snapshot.Name)
+		}
+	})
+	StateMenu.AddOption("r", "restore snapshot", func() {
+		name := StateMenu.GetString("name (or latest): ")
+		g, err := Snapshots.Load(name)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		Graph = g
+		History.Clear()
 	})
+	StateMenu.AddOption("d", "diff snapshot against current graph", func() {
+		name := StateMenu.GetString("name (or latest): ")
+		g, err := Snapshots.Load(name)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		graph.DiffGraphs(g, Graph).Print()
+	})
+	StateMenu.AddOption("df", "diff current graph against file", func() {
+		path := StateMenu.GetString("path: ")
+		g, err := graph.NewGraphFromFile(path)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		graph.DiffGraphs(Graph, g).Print()
+	})
+	StateMenu.AddOption("dp", "save patch from current graph to file", func() {
+		path := StateMenu.GetString("path: ")
+		g, err := graph.NewGraphFromFile(path)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		patchPath := StateMenu.GetString("patch path: ")
+		err = graph.DiffGraphs(Graph, g).SaveDiffToPath(patchPath)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		fmt.Printf("Saved as %s\n", patchPath)
+	})
+	StateMenu.AddOption("ap", "apply patch from file", func() {
+		path := StateMenu.GetString("patch path: ")
+		d, err := graph.NewDiffFromFile(path)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		err = History.ApplyDiff(d)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+	})
+	StateMenu.AddOption("sd", "set snapshot directory", func() {
+		Snapshots.Dir = StateMenu.GetString("directory: ")
+	})
+	StateMenu.AddOption("sl", "set snapshot limit (0 keeps all)", func() {
+		limit, err := StateMenu.GetInt("limit: ")
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		Snapshots.Limit = limit
+	})
+}
+
+// asks for the kind of graph and its parameters and generates it
+func generateGraph() (graph.Graph, error) {
+	kind := StateMenu.GetString("kind (complete, path, cycle, star, grid, random, geometric, eulerian): ")
+	seed, err := StateMenu.GetInt("seed: ")
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	var weights generate.Weights
+	if kind != "geometric" {
+		min, err := StateMenu.GetInt("min weight: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		max, err := StateMenu.GetInt("max weight: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		weights, err = generate.Uniform(min, max)
+		if err != nil {
+			return graph.Graph{}, err
+		}
+	}
+	gen := generate.NewGenerator(uint64(seed), weights)
+
+	if kind == "grid" {
+		rows, err := StateMenu.GetInt("rows: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		cols, err := StateMenu.GetInt("cols: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		return gen.Grid(rows, cols)
+	}
+	n, err := StateMenu.GetInt("nodes: ")
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	switch kind {
+	case "complete":
+		return gen.Complete(n)
+	case "path":
+		return gen.Path(n)
+	case "cycle":
+		return gen.Cycle(n)
+	case "star":
+		return gen.Star(n)
+	case "random":
+		p, err := StateMenu.GetFloat("edge probability: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		return gen.ErdosRenyi(n, p)
+	case "geometric":
+		side, err := StateMenu.GetFloat("side: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		radius, err := StateMenu.GetFloat("radius: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		return gen.Geometric(n, side, radius)
+	case "eulerian":
+		extra, err := StateMenu.GetInt("extra edges: ")
+		if err != nil {
+			return graph.Graph{}, err
+		}
+		return gen.Eulerian(n, extra)
+	}
+	return graph.Graph{}, fmt.Errorf("unknown kind of graph: %s", kind)
 }
@@ -1,14 +1,22 @@
 package menus
 
 import (
+	"errors"
 	"fmt"
+	"graph/pkg/graph"
 	"graph/pkg/traverse"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/pinguin-frosch/menu/pkg/menu"
 )
 
 var TraverseMenu *menu.Menu
 var traverseManager traverse.TraverseManager
+var verbose bool
 
 func init() {
 	TraverseMenu = menu.NewMenu("traverse")
@@ -88,8 +96,306 @@ func init() {
 		}
 		s.Print()
 	})
+	TraverseMenu.AddOption("bl", "bfs levels from one or more nodes", func() {
+		ids := strings.Fields(GraphMenu.GetString("from (space separated): "))
+		sources := make([]graph.Node, 0, len(ids))
+		for _, id := range ids {
+			node, err := Graph.GetNode(id)
+			if err != nil {
+				fmt.Printf("error: %s\n", err.Error())
+				return
+			}
+			sources = append(sources, node)
+		}
+		tree, err := traverse.MultiSourceBfs(Graph, sources)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		for hops, level := range tree.Levels {
+			fmt.Printf("%d:", hops)
+			for _, node := range level {
+				fmt.Printf(" %s", node.Id)
+			}
+			fmt.Println()
+		}
+		unreached := len(Graph.GetAllNodes()) - len(tree.Hops)
+		if unreached > 0 {
+			fmt.Printf("%d nodes not reached\n", unreached)
+		}
+	})
+	TraverseMenu.AddOption("dfs", "depth first search from a node", func() {
+		fromId := GraphMenu.GetString("from: ")
+		from, err := Graph.GetNode(fromId)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		traverse.Dfs(&Graph, from, traverse.DfsVisitor{
+			Discover: func(node graph.Node) {
+				fmt.Printf("discover %s\n", node.Id)
+			},
+			Finish: func(node graph.Node) {
+				fmt.Printf("finish %s\n", node.Id)
+			},
+			BackEdge: func(edge graph.Edge) {
+				fmt.Printf("back edge %s -> %s\n", edge.From.Id, edge.To.Id)
+			},
+		})
+	})
+	TraverseMenu.AddOption("mk", "minimum spanning tree using kruskal", func() {
+		tree, total, err := traverse.Kruskal(Graph)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		tree.Print()
+		fmt.Printf("weight: %d\n", total)
+	})
+	TraverseMenu.AddOption("mp", "minimum spanning tree using prim", func() {
+		tree, total, err := traverse.Prim(Graph)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		tree.Print()
+		fmt.Printf("weight: %d\n", total)
+	})
+	TraverseMenu.AddOption("cb", "top nodes by betweenness centrality", func() {
+		n, err := TraverseMenu.GetInt("top: ")
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		printNodeScores(traverse.TopNodeScores(traverse.BetweennessCentrality(Graph), n))
+	})
+	TraverseMenu.AddOption("cc", "top nodes by closeness centrality", func() {
+		n, err := TraverseMenu.GetInt("top: ")
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		scores, err := traverse.ClosenessCentrality(Graph)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		printNodeScores(traverse.TopNodeScores(scores, n))
+	})
+	TraverseMenu.AddOption("cd", "top nodes by degree centrality", func() {
+		n, err := TraverseMenu.GetInt("top: ")
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		printNodeScores(traverse.TopNodeScores(traverse.DegreeCentrality(Graph), n))
+	})
+	TraverseMenu.AddOption("ce", "top edges by betweenness centrality", func() {
+		n, err := TraverseMenu.GetInt("top: ")
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		for _, score := range traverse.TopEdgeScores(traverse.EdgeBetweennessCentrality(Graph), n) {
+			fmt.Printf("%s-%s[%d]: %.4f\n", score.Edge.From.Id, score.Edge.To.Id, score.Edge.Id, score.Score)
+		}
+	})
+	TraverseMenu.AddOption("tsp", "closed tour visiting a set of nodes", func() {
+		ids := strings.Fields(TraverseMenu.GetString("nodes: "))
+		nodes := make([]graph.Node, 0, len(ids))
+		for _, id := range ids {
+			node, err := Graph.GetNode(id)
+			if err != nil {
+				fmt.Printf("error: %s: %s\n", err.Error(), id)
+				return
+			}
+			nodes = append(nodes, node)
+		}
+		solvers := map[string]func(graph.View, []graph.Node) (traverse.Sequence, error){
+			"":          traverse.TSP,
+			"exact":     traverse.HeldKarpTSP,
+			"heuristic": traverse.HeuristicTSP,
+		}
+		method := TraverseMenu.GetString("method (empty, exact or heuristic): ")
+		solver, ok := solvers[method]
+		if !ok {
+			fmt.Printf("error: unknown method %s\n", method)
+			return
+		}
+		s, err := solver(Graph, nodes)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		s.Print()
+	})
+	TraverseMenu.AddOption("mf", "maximum flow and minimum cut between two nodes", func() {
+		sourceId := GraphMenu.GetString("source: ")
+		source, err := Graph.GetNode(sourceId)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		sinkId := GraphMenu.GetString("sink: ")
+		sink, err := Graph.GetNode(sinkId)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		f, err := traverse.MaxFlow(Graph, source, sink)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		f.Print()
+	})
+	TraverseMenu.AddOption("cy", "print a cycle basis", func() {
+		if !traverse.HasCycle(Graph) {
+			fmt.Println("the graph has no cycles")
+			return
+		}
+		for _, s := range traverse.CycleBasis(Graph) {
+			s.Print()
+		}
+	})
+	TraverseMenu.AddOption("cs", "shortest cycle through a node", func() {
+		id := GraphMenu.GetString("node: ")
+		node, err := Graph.GetNode(id)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		s, err := traverse.ShortestCycle(Graph, node)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		s.Print()
+	})
 	TraverseMenu.AddOption("td", "use default traverse method", func() {
 		d := traverse.NewDefault()
 		traverseManager.SetTraverseAlgorithm(d)
 	})
+	TraverseMenu.AddOption("w", "watch dijkstra, bfs or euler step by step", func() {
+		algorithm := TraverseMenu.GetString("algorithm (d, bfs, e): ")
+		if algorithm != "d" && algorithm != "bfs" && algorithm != "e" {
+			fmt.Printf("error: unknown algorithm %s\n", algorithm)
+			return
+		}
+		fromId := GraphMenu.GetString("from: ")
+		from, err := Graph.GetNode(fromId)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		var to graph.Node
+		if algorithm != "e" {
+			toId := GraphMenu.GetString("to: ")
+			to, err = Graph.GetNode(toId)
+			if err != nil {
+				fmt.Printf("error: %s\n", err.Error())
+				return
+			}
+		}
+
+		options := traverse.Options{Tracer: watchSteps()}
+		var s traverse.Sequence
+		switch algorithm {
+		case "d":
+			s, err = traverse.DijkstraWithOptions(Graph, from, to, options)
+		case "bfs":
+			s, err = traverse.BfsWithOptions(Graph, from, to, options)
+		case "e":
+			s, err = traverse.EulerWithOptions(Graph, from, options)
+		}
+		if errors.Is(err, errWatchAborted) {
+			fmt.Println("aborted")
+			return
+		}
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		s.Print()
+	})
+	TraverseMenu.AddOption("v", "toggle verbose mode, printing the steps of the algorithms", func() {
+		setVerbose(!verbose)
+		if verbose {
+			fmt.Println("verbose mode on")
+		} else {
+			fmt.Println("verbose mode off")
+		}
+	})
+}
+
+// makes the algorithms log what they do and print each step they take
+func setVerbose(on bool) {
+	verbose = on
+	if !on {
+		traverse.SetLogger(nil)
+		traverse.SetTracer(nil)
+		return
+	}
+	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
+	traverse.SetLogger(slog.New(handler))
+	traverse.SetTracer(func(step traverse.Step) error {
+		fmt.Println(step)
+		return nil
+	})
+}
+
+var errWatchAborted = errors.New("aborted while watching the algorithm")
+
+// returns a tracer that prints every step and waits for the user before
+// continuing, until asked to run to the end or to abort
+func watchSteps() traverse.Tracer {
+	run := false
+	return func(step traverse.Step) error {
+		printStep(step)
+		if run {
+			return nil
+		}
+		switch TraverseMenu.GetString("[enter] next step, [r] run to end, [a] abort: ") {
+		case "r":
+			run = true
+		case "a":
+			return errWatchAborted
+		}
+		return nil
+	}
+}
+
+// prints the step with the state of the algorithm after it
+func printStep(step traverse.Step) {
+	fmt.Println(step)
+	if step.Node.Id != "" {
+		fmt.Printf("  current: %s\n", step.Node.Id)
+	}
+	if step.Frontier != nil {
+		fmt.Print("  frontier:")
+		for _, node := range step.Frontier {
+			fmt.Printf(" %s", node.Id)
+		}
+		fmt.Println()
+	}
+	if step.Distances != nil {
+		fmt.Print("  distances:")
+		for _, id := range slices.Sorted(maps.Keys(step.Distances)) {
+			fmt.Printf(" %s:%d", id, step.Distances[id])
+		}
+		fmt.Println()
+	}
+	if step.Visited != nil {
+		fmt.Print("  visited:")
+		for _, edge := range step.Visited {
+			fmt.Printf(" %s-%s[%d]", edge.From.Id, edge.To.Id, edge.Id)
+		}
+		fmt.Println()
+	}
+}
+
+func printNodeScores(scores []traverse.NodeScore) {
+	for _, score := range scores {
+		fmt.Printf("%s: %.4f\n", score.Node.Id, score.Score)
+	}
 }
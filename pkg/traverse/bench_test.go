@@ -0,0 +1,116 @@
+package traverse_test
+
+import (
+	"fmt"
+	"graph/pkg/generate"
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+type benchmarkGraph struct {
+	name string
+	g    graph.Graph
+	from graph.Node
+	to   graph.Node
+}
+
+// returns generated city grids of growing size and the real city graph, from
+// and to are far away from each other
+func benchmarkGraphs(b *testing.B, sizes ...int) []benchmarkGraph {
+	b.Helper()
+	graphs := make([]benchmarkGraph, 0)
+	weights, _ := generate.Uniform(1, 100)
+	gen := generate.NewGenerator(1, weights)
+	for _, size := range sizes {
+		g, err := gen.Grid(size, size)
+		if err != nil {
+			b.Fatalf("Grid(%v, %v) failed: %v", size, size, err)
+		}
+		from, _ := g.GetNode("r0c0")
+		to, _ := g.GetNode(fmt.Sprintf("r%dc%d", size-1, size-1))
+		graphs = append(graphs, benchmarkGraph{fmt.Sprintf("grid-%dx%d", size, size), g, from, to})
+	}
+	g, err := graph.NewGraphFromFile("../../graphs/sb.json")
+	if err != nil {
+		b.Fatalf("NewGraphFromFile(sb.json) failed: %v", err)
+	}
+	nodes := g.GetAllNodes()
+	return append(graphs, benchmarkGraph{"sb", g, nodes[0], nodes[len(nodes)-1]})
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	for _, bg := range benchmarkGraphs(b, 10, 30, 100) {
+		b.Run(bg.name, func(b *testing.B) {
+			for range b.N {
+				_, err := traverse.Dijkstra(bg.g, bg.from, bg.to)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBfs(b *testing.B) {
+	for _, bg := range benchmarkGraphs(b, 10, 30, 100) {
+		b.Run(bg.name, func(b *testing.B) {
+			for range b.N {
+				_, err := traverse.Bfs(bg.g, bg.from, bg.to)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEuler(b *testing.B) {
+	// the real city has too many odd nodes to pair them all, so only graphs
+	// that are already eulerian are used
+	weights, _ := generate.Uniform(1, 100)
+	gen := generate.NewGenerator(1, weights)
+	for _, n := range []int{5, 9, 15} {
+		g, err := gen.Complete(n)
+		if err != nil {
+			b.Fatalf("Complete(%v) failed: %v", n, err)
+		}
+		b.Run(fmt.Sprintf("complete-%d", n), func(b *testing.B) {
+			start, _ := g.GetNode("n0")
+			for range b.N {
+				_, err := traverse.Euler(g, start)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+	g, err := graph.NewGraphFromFile("../../graphs/euler-matrix.json")
+	if err != nil {
+		b.Fatalf("NewGraphFromFile(euler-matrix.json) failed: %v", err)
+	}
+	b.Run("euler-matrix", func(b *testing.B) {
+		start := g.GetAllNodes()[0]
+		for range b.N {
+			_, err := traverse.Euler(g, start)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetShortestSequence(b *testing.B) {
+	tm := traverse.TraverseManager{}
+	tm.SetTraverseAlgorithm(traverse.NewDefault())
+	for _, bg := range benchmarkGraphs(b, 5, 9) {
+		b.Run(bg.name, func(b *testing.B) {
+			for range b.N {
+				_, err := tm.GetShortestSequence(bg.g)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
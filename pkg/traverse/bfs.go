@@ -1,70 +1,165 @@
 package traverse
 
 import (
-	"fmt"
-	"graph/pkg/collections"
+	"cmp"
 	"graph/pkg/graph"
+	"maps"
 	"slices"
 )
 
-type bfsState struct {
-	nodes map[string]*nodeState
+// result of a breadth first search, every reached node with the edge used to reach it
+type BfsTree struct {
+	// nodes where the search started
+	Sources []graph.Node
+	// number of edges from the closest source, only reached nodes are present
+	Hops map[string]int
+	// lightest edge used to reach each node from the previous level, sources have none
+	Parents map[string]graph.Edge
+	// reached nodes grouped by hops in ascending order by id, the first level are the sources
+	Levels [][]graph.Node
 }
 
-func newBfsState() bfsState {
-	bs := bfsState{}
-	bs.nodes = make(map[string]*nodeState)
-	return bs
+// runs a breadth first search from start, the whole component of start is explored
+func BfsFrom(g graph.View, start graph.Node) (BfsTree, error) {
+	return MultiSourceBfs(g, []graph.Node{start})
 }
 
-func Bfs(g graph.Graph, start, end graph.Node) (Sequence, error) {
-	// setup initial values
-	bs := newBfsState()
-	nodes := g.GetAllNodes()
-	for _, node := range nodes {
-		if _, ok := bs.nodes[node.Id]; !ok {
-			bs.nodes[node.Id] = &nodeState{}
-		}
-	}
-	q := collections.NewQueue[graph.Node]()
-
-	// add starting node
-	q.Enqueue(start)
+// runs a breadth first search starting from all sources at once, each node is
+// reached from its closest source in number of edges
+func MultiSourceBfs(g graph.View, sources []graph.Node) (BfsTree, error) {
+	return MultiSourceBfsWithOptions(g, sources, Options{})
+}
 
-	var x graph.Node
-	for !q.Empty() {
-		// get first node on queue
-		x, _ = q.Dequeue()
+// same as MultiSourceBfs, reporting to the tracer of the options
+func MultiSourceBfsWithOptions(g graph.View, sources []graph.Node, o Options) (BfsTree, error) {
+	return multiSourceBfs(g, sources, o.resolve().Tracer)
+}
 
-		// found end node
-		if x.Id == end.Id {
-			break
+// runs the search reporting every step to tracer when it is not nil
+func multiSourceBfs(g graph.View, sources []graph.Node, tracer Tracer) (BfsTree, error) {
+	t := BfsTree{
+		Sources: make([]graph.Node, 0, len(sources)),
+		Hops:    make(map[string]int),
+		Parents: make(map[string]graph.Edge),
+		Levels:  make([][]graph.Node, 0),
+	}
+	level := make([]graph.Node, 0, len(sources))
+	for _, source := range sources {
+		node, err := g.GetNode(source.Id)
+		if err != nil {
+			return BfsTree{}, err
 		}
+		if _, ok := t.Hops[node.Id]; ok {
+			continue
+		}
+		t.Sources = append(t.Sources, node)
+		t.Hops[node.Id] = 0
+		level = append(level, node)
+	}
 
-		// get all neighbours from x
-		nodes := g.GetNodes(x)
-		for _, node := range nodes {
-			if !bs.nodes[node.Id].visited {
-				bs.nodes[node.Id].visited = true
-				bs.nodes[node.Id].prev = x
-				q.Enqueue(node)
+	// every level is finished before the next one starts, nodes and edges are
+	// visited in a fixed order so the tree does not depend on map iteration
+	for hops := 1; len(level) > 0; hops++ {
+		slices.SortFunc(level, func(a, b graph.Node) int {
+			return cmp.Compare(a.Id, b.Id)
+		})
+		t.Levels = append(t.Levels, level)
+		next := make([]graph.Node, 0)
+		for i, x := range level {
+			err := report(tracer, func() Step {
+				return bfsStep(StepNodeVisited, x, graph.Edge{}, t, level[i+1:], next)
+			})
+			if err != nil {
+				return BfsTree{}, err
+			}
+			edges := g.GetEdges(x)
+			slices.SortFunc(edges, compareEdges)
+			for _, edge := range edges {
+				if _, ok := t.Hops[edge.To.Id]; ok {
+					continue
+				}
+				node, _ := g.GetNode(edge.To.Id)
+				t.Hops[node.Id] = hops
+				t.Parents[node.Id] = edge
+				next = append(next, node)
+				err := report(tracer, func() Step {
+					return bfsStep(StepNodeReached, node, edge, t, level[i+1:], next)
+				})
+				if err != nil {
+					return BfsTree{}, err
+				}
 			}
 		}
+		level = next
 	}
+	return t, nil
+}
 
-	// reconstruct the sequence from the queue
+// returns a step of the search with the nodes of the current level still to be
+// visited followed by the ones of the next level, the edges are the tree so far
+func bfsStep(kind StepKind, node graph.Node, edge graph.Edge, t BfsTree, level, next []graph.Node) Step {
+	frontier := make([]graph.Node, 0, len(level)+len(next))
+	frontier = append(append(frontier, level...), next...)
+	visited := slices.AppendSeq(make([]graph.Edge, 0, len(t.Parents)), maps.Values(t.Parents))
+	slices.SortFunc(visited, compareEdges)
+	return Step{Kind: kind, Node: node, Edge: edge, Distance: t.Hops[node.Id], Frontier: frontier, Distances: maps.Clone(t.Hops), Visited: visited}
+}
+
+// returns the number of edges from start to every node it can reach
+func HopDistances(g graph.View, start graph.Node) (map[string]int, error) {
+	t, err := BfsFrom(g, start)
+	if err != nil {
+		return nil, err
+	}
+	return t.Hops, nil
+}
+
+// indicates if the search reached node
+func (t BfsTree) Reached(node graph.Node) bool {
+	_, ok := t.Hops[node.Id]
+	return ok
+}
+
+// returns the path with the fewest edges from the closest source to node, the
+// distance is the sum of the weights of the tree edges
+func (t BfsTree) PathTo(node graph.Node) (Sequence, error) {
+	if !t.Reached(node) {
+		var from graph.Node
+		if len(t.Sources) > 0 {
+			from = t.Sources[0]
+		}
+		return Sequence{}, &UnreachableError{From: from, Nodes: []graph.Node{node}}
+	}
 	s := NewSequence()
-	s.Sequence = append(s.Sequence, x)
-	for x.Id != start.Id {
-		prev := bs.nodes[x.Id].prev
-		edge, ok := g.GetShortestEdge(x, prev)
+	x := node.Id
+	for {
+		edge, ok := t.Parents[x]
 		if !ok {
-			return Sequence{}, fmt.Errorf("couldn't get shortest edge between %v and %v", x.Id, prev.Id)
+			break
 		}
+		s.Sequence = append(s.Sequence, edge.To)
 		s.Distance += edge.Weight
-		x = prev
-		s.Sequence = append(s.Sequence, x)
+		x = edge.From.Id
 	}
+	s.Sequence = append(s.Sequence, graph.Node{Id: x})
 	slices.Reverse(s.Sequence)
 	return s, nil
 }
+
+// returns the path with the fewest edges between start and end
+func Bfs(g graph.View, start, end graph.Node) (Sequence, error) {
+	return BfsWithOptions(g, start, end, Options{})
+}
+
+// same as Bfs, reporting to the tracer of the options
+func BfsWithOptions(g graph.View, start, end graph.Node, o Options) (Sequence, error) {
+	_, err := g.GetNode(end.Id)
+	if err != nil {
+		return Sequence{}, err
+	}
+	t, err := MultiSourceBfsWithOptions(g, []graph.Node{start}, o)
+	if err != nil {
+		return Sequence{}, err
+	}
+	return t.PathTo(end)
+}
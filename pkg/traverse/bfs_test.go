@@ -0,0 +1,98 @@
+package traverse_test
+
+import (
+	"errors"
+	"fmt"
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+func TestBfsFrom(t *testing.T) {
+	g := newGridGraph(3, 3)
+	start, _ := g.GetNode("r0c0")
+	tree, err := traverse.BfsFrom(g, start)
+	if err != nil {
+		t.Fatalf("BfsFrom(g, r0c0) failed: %v", err)
+	}
+	// hops in a grid are the city block distance
+	for _, node := range g.GetAllNodes() {
+		var r, c int
+		_, _ = fmt.Sscanf(node.Id, "r%dc%d", &r, &c)
+		if tree.Hops[node.Id] != r+c {
+			t.Fatalf("BfsFrom(g, r0c0) hops to %v = %v, want %v", node.Id, tree.Hops[node.Id], r+c)
+		}
+	}
+	if len(tree.Levels) != 5 || len(tree.Levels[2]) != 3 {
+		t.Fatalf("BfsFrom(g, r0c0) levels = %v, want 5 levels with 3 nodes in the middle", tree.Levels)
+	}
+	if _, ok := tree.Parents["r0c0"]; ok || len(tree.Parents) != 8 {
+		t.Fatalf("BfsFrom(g, r0c0) should have a parent edge for every node except the start")
+	}
+
+	end, _ := g.GetNode("r2c2")
+	s, err := traverse.Bfs(g, start, end)
+	if err != nil {
+		t.Fatalf("Bfs(g, r0c0, r2c2) failed: %v", err)
+	}
+	if len(s.Sequence) != 5 || s.Sequence[0].Id != "r0c0" || s.Sequence[4].Id != "r2c2" {
+		t.Fatalf("Bfs(g, r0c0, r2c2) = %v, want 4 edges from r0c0 to r2c2", s.Sequence)
+	}
+	if s.Distance != 6 {
+		t.Fatalf("Bfs(g, r0c0, r2c2) distance = %v, want %v", s.Distance, 6)
+	}
+
+	s, err = traverse.Bfs(g, start, start)
+	if err != nil || len(s.Sequence) != 1 || s.Distance != 0 {
+		t.Fatalf("Bfs(g, r0c0, r0c0) = %v, %v, want only the start", s, err)
+	}
+}
+
+func TestMultiSourceBfs(t *testing.T) {
+	g := newGridGraph(1, 7)
+	a, _ := g.GetNode("r0c0")
+	b, _ := g.GetNode("r0c6")
+	tree, err := traverse.MultiSourceBfs(g, []graph.Node{a, b, a})
+	if err != nil {
+		t.Fatalf("MultiSourceBfs(g, r0c0, r0c6) failed: %v", err)
+	}
+	if len(tree.Sources) != 2 {
+		t.Fatalf("MultiSourceBfs(g, r0c0, r0c6) sources = %v, repeated sources should be ignored", tree.Sources)
+	}
+	want := []int{0, 1, 2, 3, 2, 1, 0}
+	for c, hops := range want {
+		id := fmt.Sprintf("r0c%d", c)
+		if tree.Hops[id] != hops {
+			t.Fatalf("MultiSourceBfs(g, r0c0, r0c6) hops to %v = %v, want %v", id, tree.Hops[id], hops)
+		}
+	}
+	s, _ := tree.PathTo(graph.Node{Id: "r0c5"})
+	if s.Sequence[0].Id != "r0c6" {
+		t.Fatalf("PathTo(r0c5) = %v, want a path from the closest source", s.Sequence)
+	}
+}
+
+func TestBfsUnreachable(t *testing.T) {
+	g := newGridGraph(1, 3)
+	island, _ := graph.NewNode("island")
+	_ = g.AddNode(island)
+	start, _ := g.GetNode("r0c0")
+
+	hops, err := traverse.HopDistances(g, start)
+	if err != nil || len(hops) != 3 {
+		t.Fatalf("HopDistances(g, r0c0) = %v, %v, want the 3 street nodes", hops, err)
+	}
+	var unreachable *traverse.UnreachableError
+	_, err = traverse.Bfs(g, start, island)
+	if !errors.As(err, &unreachable) || unreachable.Nodes[0].Id != "island" {
+		t.Fatalf("Bfs(g, r0c0, island) error = %v, want an UnreachableError", err)
+	}
+
+	missing := graph.Node{Id: "missing"}
+	if _, err = traverse.Bfs(g, start, missing); err == nil {
+		t.Fatalf("Bfs(g, r0c0, missing) should fail for a node not in the graph")
+	}
+	if _, err = traverse.BfsFrom(g, missing); err == nil {
+		t.Fatalf("BfsFrom(g, missing) should fail for a node not in the graph")
+	}
+}
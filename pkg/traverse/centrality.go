@@ -0,0 +1,186 @@
+package traverse
+
+import (
+	"cmp"
+	"graph/pkg/collections"
+	"graph/pkg/graph"
+	"slices"
+)
+
+type NodeScore struct {
+	Node  graph.Node
+	Score float64
+}
+
+type EdgeScore struct {
+	Edge  graph.Edge
+	Score float64
+}
+
+// identifies an undirected edge, parallel edges differ by id
+type undirectedKey struct {
+	from string
+	to   string
+	id   int
+}
+
+func keyOfEdge(edge graph.Edge) undirectedKey {
+	if edge.From.Id > edge.To.Id {
+		return undirectedKey{edge.To.Id, edge.From.Id, edge.Id}
+	}
+	return undirectedKey{edge.From.Id, edge.To.Id, edge.Id}
+}
+
+type predecessor struct {
+	node graph.Node
+	edge graph.Edge
+}
+
+// accumulates the betweenness of nodes and edges using brandes' algorithm over
+// weighted shortest paths, every pair of nodes is counted once
+func brandes(g graph.View) (map[string]float64, map[undirectedKey]float64) {
+	nodeScores := make(map[string]float64)
+	edgeScores := make(map[undirectedKey]float64)
+	for _, s := range g.GetAllNodes() {
+		settled := collections.NewStack[graph.Node]()
+		preds := make(map[string][]predecessor)
+		paths := map[string]float64{s.Id: 1}
+		distances := map[string]int{s.Id: 0}
+		done := make(map[string]bool)
+		pq := collections.NewPriorityQueue(func(x, y distanceItem) bool {
+			if x.distance != y.distance {
+				return x.distance < y.distance
+			}
+			return x.node.Id < y.node.Id
+		})
+		pq.Push(distanceItem{s, 0})
+		for !pq.Empty() {
+			item, _ := pq.Pop()
+			v := item.node
+			if done[v.Id] {
+				continue
+			}
+			done[v.Id] = true
+			settled.Push(v)
+			for _, edge := range g.GetEdges(v) {
+				w := edge.To
+				distance := distances[v.Id] + edge.Weight
+				d, seen := distances[w.Id]
+				if !seen || distance < d {
+					distances[w.Id] = distance
+					paths[w.Id] = paths[v.Id]
+					preds[w.Id] = []predecessor{{v, edge}}
+					pq.Push(distanceItem{w, distance})
+				} else if distance == d && !done[w.Id] {
+					paths[w.Id] += paths[v.Id]
+					preds[w.Id] = append(preds[w.Id], predecessor{v, edge})
+				}
+			}
+		}
+
+		// go back from the farthest nodes sharing their dependency with their predecessors
+		dependency := make(map[string]float64)
+		for !settled.Empty() {
+			w, _ := settled.Pop()
+			for _, p := range preds[w.Id] {
+				c := paths[p.node.Id] / paths[w.Id] * (1 + dependency[w.Id])
+				edgeScores[keyOfEdge(p.edge)] += c
+				dependency[p.node.Id] += c
+			}
+			if w.Id != s.Id {
+				nodeScores[w.Id] += dependency[w.Id]
+			}
+		}
+	}
+	// each path was found from both of its ends
+	for id := range nodeScores {
+		nodeScores[id] /= 2
+	}
+	for key := range edgeScores {
+		edgeScores[key] /= 2
+	}
+	return nodeScores, edgeScores
+}
+
+// returns how many shortest paths between other nodes go through each node, in
+// ascending order by id
+func BetweennessCentrality(g graph.View) []NodeScore {
+	nodeScores, _ := brandes(g)
+	scores := make([]NodeScore, 0)
+	for _, node := range g.GetAllNodes() {
+		scores = append(scores, NodeScore{node, nodeScores[node.Id]})
+	}
+	return scores
+}
+
+// returns how many shortest paths go through each edge, every edge appears once
+// with its nodes in ascending order by id
+func EdgeBetweennessCentrality(g graph.View) []EdgeScore {
+	_, edgeScores := brandes(g)
+	scores := make([]EdgeScore, 0)
+	for _, edge := range g.GetAllEdges() {
+		if edge.From.Id < edge.To.Id {
+			scores = append(scores, EdgeScore{edge, edgeScores[keyOfEdge(edge)]})
+		}
+	}
+	slices.SortFunc(scores, func(a, b EdgeScore) int {
+		ka, kb := keyOfEdge(a.Edge), keyOfEdge(b.Edge)
+		return cmp.Or(cmp.Compare(ka.from, kb.from), cmp.Compare(ka.to, kb.to), cmp.Compare(ka.id, kb.id))
+	})
+	return scores
+}
+
+// returns the inverse of the average distance from each node to the nodes it can
+// reach, in ascending order by id, isolated nodes score 0
+func ClosenessCentrality(g graph.View) ([]NodeScore, error) {
+	scores := make([]NodeScore, 0)
+	for _, node := range g.GetAllNodes() {
+		distances, err := ShortestDistances(g, node)
+		if err != nil {
+			return nil, err
+		}
+		total := 0
+		for _, d := range distances {
+			total += d
+		}
+		score := 0.0
+		if total > 0 {
+			score = float64(len(distances)-1) / float64(total)
+		}
+		scores = append(scores, NodeScore{node, score})
+	}
+	return scores, nil
+}
+
+// returns the degree of each node divided by the number of other nodes, in
+// ascending order by id
+func DegreeCentrality(g graph.View) []NodeScore {
+	nodes := g.GetAllNodes()
+	scores := make([]NodeScore, 0, len(nodes))
+	for _, node := range nodes {
+		score := 0.0
+		if len(nodes) > 1 {
+			score = float64(g.Degree(node)) / float64(len(nodes)-1)
+		}
+		scores = append(scores, NodeScore{node, score})
+	}
+	return scores
+}
+
+// returns the n nodes with the highest scores, ties are ordered by id
+func TopNodeScores(scores []NodeScore, n int) []NodeScore {
+	top := slices.Clone(scores)
+	slices.SortStableFunc(top, func(a, b NodeScore) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Node.Id, b.Node.Id))
+	})
+	return top[:max(0, min(n, len(top)))]
+}
+
+// returns the n edges with the highest scores, ties keep their order
+func TopEdgeScores(scores []EdgeScore, n int) []EdgeScore {
+	top := slices.Clone(scores)
+	slices.SortStableFunc(top, func(a, b EdgeScore) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return top[:max(0, min(n, len(top)))]
+}
@@ -0,0 +1,58 @@
+package traverse_test
+
+import (
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+// a square a-b-d-c-a with a street from d to e
+func newCentralityGraph(t *testing.T) graph.Graph {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d", "e")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["d"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["c"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["d"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["d"], nodes["e"], 1))
+	return g
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	g := newCentralityGraph(t)
+	// d is in every path to e, b and c split the paths from a to d and e,
+	// a and d split the paths between b and c
+	expected := map[string]float64{"a": 0.5, "b": 1, "c": 1, "d": 3.5, "e": 0}
+	for _, score := range traverse.BetweennessCentrality(g) {
+		if score.Score != expected[score.Node.Id] {
+			t.Fatalf("BetweennessCentrality(g)[%v] = %v, want %v", score.Node.Id, score.Score, expected[score.Node.Id])
+		}
+	}
+
+	top := traverse.TopNodeScores(traverse.BetweennessCentrality(g), 3)
+	if len(top) != 3 || top[0].Node.Id != "d" || top[1].Node.Id != "b" || top[2].Node.Id != "c" {
+		t.Fatalf("TopNodeScores() = %v, want d, b, c", top)
+	}
+
+	// every node reaches e through d-e
+	edges := traverse.TopEdgeScores(traverse.EdgeBetweennessCentrality(g), 1)
+	if edges[0].Edge.From.Id != "d" || edges[0].Edge.To.Id != "e" || edges[0].Score != 4 {
+		t.Fatalf("TopEdgeScores() = %v, want d-e with score %v", edges, 4)
+	}
+}
+
+func TestClosenessCentrality(t *testing.T) {
+	g := newCentralityGraph(t)
+	scores, err := traverse.ClosenessCentrality(g)
+	if err != nil {
+		t.Fatalf("ClosenessCentrality(g) failed: %v", err)
+	}
+	// d is at distance 1 from b, c and e and 2 from a
+	if scores[3].Node.Id != "d" || scores[3].Score != 4.0/5.0 {
+		t.Fatalf("ClosenessCentrality(g)[d] = %v, want %v", scores[3], 4.0/5.0)
+	}
+
+	degrees := traverse.DegreeCentrality(g)
+	if degrees[3].Score != 3.0/4.0 {
+		t.Fatalf("DegreeCentrality(g)[d] = %v, want %v", degrees[3], 3.0/4.0)
+	}
+}
@@ -0,0 +1,84 @@
+package traverse
+
+import (
+	"errors"
+	"fmt"
+	"graph/pkg/graph"
+	"strings"
+)
+
+var (
+	ErrUnreachableNodes = errors.New("nodes cannot be reached")
+)
+
+// returned when a traversal needs nodes that cannot be reached from where it starts
+type UnreachableError struct {
+	From  graph.Node
+	Nodes []graph.Node
+}
+
+func (e *UnreachableError) Error() string {
+	ids := make([]string, 0, len(e.Nodes))
+	for _, node := range e.Nodes {
+		ids = append(ids, node.Id)
+	}
+	return fmt.Sprintf("%s from %s: %s", ErrUnreachableNodes, e.From.Id, strings.Join(ids, " "))
+}
+
+func (e *UnreachableError) Unwrap() error {
+	return ErrUnreachableNodes
+}
+
+// returns a function that indicates if a node can be reached from start, arcs
+// of a directed graph are only followed forward
+func reachableFrom(g graph.View, start graph.Node) (func(node graph.Node) bool, error) {
+	_, err := g.GetNode(start.Id)
+	if err != nil {
+		return nil, err
+	}
+	if g.IsDirected() {
+		t, err := multiSourceBfs(g, []graph.Node{start}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return t.Reached, nil
+	}
+	labels := g.ComponentLabels()
+	return func(node graph.Node) bool {
+		return labels[node.Id] == labels[start.Id]
+	}, nil
+}
+
+// checks that every node with edges can be reached from start, isolated nodes are ignored
+func checkEdgesReachable(g graph.View, start graph.Node) error {
+	reachable, err := reachableFrom(g, start)
+	if err != nil {
+		return err
+	}
+	unreachable := make([]graph.Node, 0)
+	for _, node := range g.GetAllNodes() {
+		if !reachable(node) && g.Degree(node) > 0 {
+			unreachable = append(unreachable, node)
+		}
+	}
+	if len(unreachable) > 0 {
+		return &UnreachableError{From: start, Nodes: unreachable}
+	}
+	return nil
+}
+
+// checks that both nodes exist and that b can be reached from a
+func checkReachable(g graph.View, a, b graph.Node) error {
+	reachable, err := reachableFrom(g, a)
+	if err != nil {
+		return err
+	}
+	_, err = g.GetNode(b.Id)
+	if err != nil {
+		return err
+	}
+	if !reachable(b) {
+		return &UnreachableError{From: a, Nodes: []graph.Node{b}}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package traverse_test
+
+import (
+	"errors"
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+func TestUnreachableNodes(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d", "e")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["d"], nodes["e"], 1))
+
+	var unreachable *traverse.UnreachableError
+	_, err := traverse.Dijkstra(g, nodes["a"], nodes["d"])
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("Dijkstra(a, d) error = %v, want an UnreachableError", err)
+	}
+	if len(unreachable.Nodes) != 1 || unreachable.Nodes[0].Id != "d" {
+		t.Fatalf("Dijkstra(a, d) unreachable = %v, want only d", unreachable.Nodes)
+	}
+	if !errors.Is(err, traverse.ErrUnreachableNodes) {
+		t.Fatalf("Dijkstra(a, d) error = %v, want ErrUnreachableNodes", err)
+	}
+
+	// the other component doesn't prevent reaching nodes in the same one
+	s, err := traverse.Dijkstra(g, nodes["a"], nodes["c"])
+	if err != nil || s.Distance != 2 {
+		t.Fatalf("Dijkstra(a, c) = %v, %v, want distance %v", s, err, 2)
+	}
+
+	_, err = traverse.Bfs(g, nodes["a"], nodes["e"])
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("Bfs(a, e) error = %v, want an UnreachableError", err)
+	}
+
+	_, err = traverse.Euler(g, nodes["a"])
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("Euler(a) error = %v, want an UnreachableError", err)
+	}
+	if len(unreachable.Nodes) != 2 {
+		t.Fatalf("Euler(a) unreachable = %v, want d and e", unreachable.Nodes)
+	}
+
+	tm := traverse.TraverseManager{}
+	tm.SetTraverseAlgorithm(traverse.NewDefault())
+	_, err = tm.GetShortestSequence(g)
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("GetShortestSequence() error = %v, want an UnreachableError", err)
+	}
+	_, err = tm.GetSequence(g, nodes["a"])
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("GetSequence(a) error = %v, want an UnreachableError", err)
+	}
+}
+
+// graph whose edges can only be followed in the direction they were added
+type oneWayGraph struct {
+	graph.Graph
+}
+
+func (g oneWayGraph) GetEdges(node graph.Node) []graph.Edge {
+	edges := make([]graph.Edge, 0)
+	for _, edge := range g.Graph.GetEdges(node) {
+		if added, _ := g.GetEdgeById(edge.Id); added.From.Id == node.Id {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+func (g oneWayGraph) GetAllEdges() []graph.Edge {
+	edges := make([]graph.Edge, 0)
+	for _, node := range g.GetAllNodes() {
+		edges = append(edges, g.GetEdges(node)...)
+	}
+	return edges
+}
+
+func (g oneWayGraph) GetNodes(node graph.Node) []graph.Node {
+	nodes := make([]graph.Node, 0)
+	for _, edge := range g.GetEdges(node) {
+		nodes = append(nodes, edge.To)
+	}
+	return nodes
+}
+
+func (g oneWayGraph) Degree(node graph.Node) int {
+	return len(g.GetEdges(node))
+}
+
+func (g oneWayGraph) IsDirected() bool {
+	return true
+}
+
+func TestUnreachableNodesDirected(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["b"], 1))
+	d := oneWayGraph{g}
+
+	// b is reached from a, but nothing leads back to a or to c
+	s, err := traverse.Dijkstra(d, nodes["a"], nodes["b"])
+	if err != nil || s.Distance != 1 {
+		t.Fatalf("Dijkstra(a, b) = %v, %v, want distance %v", s, err, 1)
+	}
+	var unreachable *traverse.UnreachableError
+	_, err = traverse.Dijkstra(d, nodes["b"], nodes["a"])
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("Dijkstra(b, a) error = %v, want an UnreachableError", err)
+	}
+	_, err = traverse.Euler(d, nodes["a"])
+	if !errors.As(err, &unreachable) || len(unreachable.Nodes) != 1 || unreachable.Nodes[0].Id != "c" {
+		t.Fatalf("Euler(a) error = %v, want c unreachable", err)
+	}
+
+	// the arcs still join the nodes in one component
+	components := graph.NewOverlay(d).ConnectedComponents()
+	if len(components) != 1 {
+		t.Fatalf("ConnectedComponents() = %v, want a single component", components)
+	}
+}
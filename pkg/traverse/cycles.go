@@ -0,0 +1,114 @@
+package traverse
+
+import (
+	"errors"
+	"graph/pkg/collections"
+	"graph/pkg/graph"
+	"slices"
+)
+
+var (
+	ErrNoCycle = errors.New("there is no cycle through the node")
+)
+
+// indicates if the graph has a cycle, parallel edges form a cycle
+func HasCycle(g graph.View) bool {
+	edges := len(uniqueEdgesByWeight(g))
+	return edges+len(g.ConnectedComponents()) > len(g.GetAllNodes())
+}
+
+// returns a fundamental cycle basis, one cycle for each edge left out of a
+// breadth first spanning forest, each cycle starts and ends at the node where
+// its two tree paths meet
+func CycleBasis(g graph.View) []Sequence {
+	parents := make(map[string]graph.Edge)
+	depths := make(map[string]int)
+	treeEdges := make(map[undirectedKey]bool)
+	for _, root := range g.GetAllNodes() {
+		if _, ok := depths[root.Id]; ok {
+			continue
+		}
+		depths[root.Id] = 0
+		q := collections.NewQueue[graph.Node]()
+		q.Enqueue(root)
+		for !q.Empty() {
+			x, _ := q.Dequeue()
+			for _, edge := range g.GetEdges(x) {
+				if _, ok := depths[edge.To.Id]; ok {
+					continue
+				}
+				depths[edge.To.Id] = depths[x.Id] + 1
+				parents[edge.To.Id] = edge
+				treeEdges[keyOfEdge(edge)] = true
+				q.Enqueue(edge.To)
+			}
+		}
+	}
+
+	cycles := make([]Sequence, 0)
+	for _, edge := range uniqueEdgesByWeight(g) {
+		if treeEdges[keyOfEdge(edge)] {
+			continue
+		}
+		// climb from both ends until the paths meet
+		s := NewSequence()
+		s.Distance = edge.Weight
+		left := []graph.Node{edge.From}
+		right := []graph.Node{edge.To}
+		u, v := edge.From, edge.To
+		for u.Id != v.Id {
+			if depths[u.Id] >= depths[v.Id] {
+				s.Distance += parents[u.Id].Weight
+				u = parents[u.Id].From
+				left = append(left, u)
+			} else {
+				s.Distance += parents[v.Id].Weight
+				v = parents[v.Id].From
+				right = append(right, v)
+			}
+		}
+		// left goes from the edge up to the meeting node, right goes down to it again
+		slices.Reverse(left)
+		s.Sequence = append(s.Sequence, left...)
+		s.Sequence = append(s.Sequence, right...)
+		cycles = append(cycles, s)
+	}
+	return cycles
+}
+
+// returns the shortest cycle that starts and ends at node
+func ShortestCycle(g graph.View, node graph.Node) (Sequence, error) {
+	_, err := g.GetNode(node.Id)
+	if err != nil {
+		return Sequence{}, err
+	}
+	best := Sequence{}
+	found := false
+	for _, first := range g.GetEdges(node) {
+		// go back to node without using the first edge again
+		skip := func(edge graph.Edge) bool {
+			return keyOfEdge(edge) == keyOfEdge(first)
+		}
+		distances, prev, err := shortestPathTreeWithout(g, first.To, skip)
+		if err != nil {
+			return Sequence{}, err
+		}
+		distance, ok := distances[node.Id]
+		if !ok || (found && first.Weight+distance >= best.Distance) {
+			continue
+		}
+		s := NewSequence()
+		s.Distance = first.Weight + distance
+		for x := node; x.Id != first.To.Id; x = prev[x.Id].From {
+			s.Sequence = append(s.Sequence, x)
+		}
+		s.Sequence = append(s.Sequence, first.To, node)
+		slices.Reverse(s.Sequence)
+		best = s
+		found = true
+	}
+	if !found {
+		return Sequence{}, &graph.NodeError{Id: node.Id, Err: ErrNoCycle}
+	}
+	return best, nil
+}
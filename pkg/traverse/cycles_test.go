@@ -0,0 +1,69 @@
+package traverse_test
+
+import (
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+func TestCycleBasis(t *testing.T) {
+	// a 4x4 grid has 9 blocks, each one is an independent cycle
+	g := newGridGraph(4, 4)
+	if !traverse.HasCycle(g) {
+		t.Fatalf("HasCycle(g) = false, want true")
+	}
+	cycles := traverse.CycleBasis(g)
+	if len(cycles) != 9 {
+		t.Fatalf("CycleBasis(g) returned %v cycles, want %v", len(cycles), 9)
+	}
+	for _, cycle := range cycles {
+		first, last := cycle.Sequence[0], cycle.Sequence[len(cycle.Sequence)-1]
+		if first.Id != last.Id {
+			t.Fatalf("CycleBasis(g) cycle %v is not closed", cycle.Sequence)
+		}
+		distance := 0
+		for i := 1; i < len(cycle.Sequence); i++ {
+			edge, ok := g.GetShortestEdge(cycle.Sequence[i-1], cycle.Sequence[i])
+			if !ok {
+				t.Fatalf("CycleBasis(g) cycle jumps from %v to %v", cycle.Sequence[i-1].Id, cycle.Sequence[i].Id)
+			}
+			distance += edge.Weight
+		}
+		if distance != cycle.Distance {
+			t.Fatalf("CycleBasis(g) cycle distance = %v, want %v", cycle.Distance, distance)
+		}
+	}
+
+	tree := newGridGraph(1, 5)
+	if traverse.HasCycle(tree) || len(traverse.CycleBasis(tree)) != 0 {
+		t.Fatalf("a path of streets should have no cycles")
+	}
+}
+
+func TestShortestCycle(t *testing.T) {
+	g := newGridGraph(3, 3)
+	corner, _ := g.GetNode("r0c0")
+	s, err := traverse.ShortestCycle(g, corner)
+	if err != nil {
+		t.Fatalf("ShortestCycle(g, r0c0) failed: %v", err)
+	}
+	// around the first block
+	if s.Distance != 6 || len(s.Sequence) != 5 {
+		t.Fatalf("ShortestCycle(g, r0c0) = %v, want the first block with distance %v", s, 6)
+	}
+
+	// a parallel street is the shortest way back
+	next, _ := g.GetNode("r0c1")
+	_ = g.AddEdge(graph.NewEdge(corner, next, 1))
+	s, err = traverse.ShortestCycle(g, corner)
+	if err != nil || s.Distance != 2 {
+		t.Fatalf("ShortestCycle(g, r0c0) = %v, %v, want distance %v", s, err, 2)
+	}
+
+	path := newGridGraph(1, 3)
+	middle, _ := path.GetNode("r0c1")
+	_, err = traverse.ShortestCycle(path, middle)
+	if err == nil {
+		t.Fatalf("ShortestCycle(path, r0c1) should fail, there are no cycles")
+	}
+}
@@ -1,11 +1,15 @@
 package traverse
 
 import (
-	"fmt"
+	"errors"
 	"graph/pkg/graph"
 	"slices"
 )
 
+var (
+	ErrNoEdges = errors.New("node has no edges")
+)
+
 type Default struct {
 	visitedEdges map[string]int
 	totalEdges   int
@@ -24,7 +28,9 @@ func (d *Default) reset() {
 	d.totalEdges = 0
 }
 
-func (d Default) getSequence(g graph.Graph, from graph.Node) (Sequence, error) {
+// the traverse manager checks that every edge can be reached from where the
+// sequence starts before calling it
+func (d Default) getSequence(g graph.View, from graph.Node) (Sequence, error) {
 	d.reset()
 	node := from
 	s := NewSequence()
@@ -39,7 +45,6 @@ func (d Default) getSequence(g graph.Graph, from graph.Node) (Sequence, error) {
 			d.usedEdges++
 		}
 		d.visitedEdges[edge.Key()]++
-		d.visitedEdges[edge.ReversedEdge().Key()]++
 		s.Distance += edge.Weight
 		s.Sequence = append(s.Sequence, edge.To)
 		node = edge.To
@@ -47,29 +52,15 @@ func (d Default) getSequence(g graph.Graph, from graph.Node) (Sequence, error) {
 	return s, nil
 }
 
-func (d *Default) CountTotalEdges(g graph.Graph) int {
-	total := 0
-	visited := make(map[string]bool)
-	nodes := g.GetAllNodes()
-	for _, node := range nodes {
-		edges := g.GetEdges(node)
-		for _, edge := range edges {
-			key := edge.Key()
-			keyReversed := edge.ReversedEdge().Key()
-			if !visited[key] && !visited[keyReversed] {
-				visited[key] = true
-				visited[keyReversed] = true
-				total += 1
-			}
-		}
-	}
-	return total
+// returns the number of edges, each edge is listed once from each of its nodes
+func (d *Default) CountTotalEdges(g graph.View) int {
+	return len(g.GetAllEdges()) / 2
 }
 
-func (d *Default) GetNextEdge(g graph.Graph, n graph.Node) (graph.Edge, error) {
+func (d *Default) GetNextEdge(g graph.View, n graph.Node) (graph.Edge, error) {
 	candidates := g.GetEdges(n)
 	if len(candidates) < 1 {
-		return graph.Edge{}, fmt.Errorf("node %s has no edges", n.Id)
+		return graph.Edge{}, &graph.NodeError{Id: n.Id, Err: ErrNoEdges}
 	}
 	slices.SortFunc(candidates, func(a, b graph.Edge) int {
 		if d.visitedEdges[a.Key()] < d.visitedEdges[b.Key()] {
@@ -0,0 +1,195 @@
+package traverse
+
+import (
+	"cmp"
+	"errors"
+	"graph/pkg/collections"
+	"graph/pkg/graph"
+	"slices"
+)
+
+var (
+	ErrGraphHasCycle = errors.New("graph has a cycle, it can not be sorted topologically")
+)
+
+// minimal view of a graph needed to search it, implemented by *graph.Graph
+// and *graph.Digraph
+type Adjacency interface {
+	GetAllNodes() []graph.Node
+	GetEdges(node graph.Node) []graph.Edge
+	IsDirected() bool
+}
+
+// callbacks called by the depth first search, nil callbacks are skipped. In
+// undirected graphs every edge is reported once, as a tree or back edge
+type DfsVisitor struct {
+	// called when the node is first reached
+	Discover func(node graph.Node)
+	// called when every edge of the node has been explored
+	Finish func(node graph.Node)
+	// edge leading to an undiscovered node
+	TreeEdge func(edge graph.Edge)
+	// edge leading to an ancestor still being explored
+	BackEdge func(edge graph.Edge)
+	// edge leading to an already finished descendant, only in directed graphs
+	ForwardEdge func(edge graph.Edge)
+	// edge leading to a finished node in another branch, only in directed graphs
+	CrossEdge func(edge graph.Edge)
+}
+
+const (
+	undiscovered = iota
+	discovered
+	finished
+)
+
+type dfsFrame struct {
+	node      graph.Node
+	edges     []graph.Edge
+	next      int
+	parent    graph.Edge
+	hasParent bool
+}
+
+type dfsState struct {
+	a        Adjacency
+	v        DfsVisitor
+	directed bool
+	colors   map[string]int
+	times    map[string]int
+	time     int
+}
+
+func newDfsState(a Adjacency, v DfsVisitor) *dfsState {
+	return &dfsState{
+		a:        a,
+		v:        v,
+		directed: a.IsDirected(),
+		colors:   make(map[string]int),
+		times:    make(map[string]int),
+	}
+}
+
+// explores every node reachable from root, it uses an explicit stack so big
+// graphs do not overflow the call stack
+func (ds *dfsState) visit(root graph.Node) {
+	if ds.colors[root.Id] != undiscovered {
+		return
+	}
+	s := collections.NewStack[*dfsFrame]()
+	ds.discover(root)
+	s.Push(&dfsFrame{node: root, edges: ds.a.GetEdges(root)})
+	for !s.Empty() {
+		f, _ := s.Peek()
+		if f.next == len(f.edges) {
+			s.Pop()
+			ds.colors[f.node.Id] = finished
+			if ds.v.Finish != nil {
+				ds.v.Finish(f.node)
+			}
+			continue
+		}
+		edge := f.edges[f.next]
+		f.next++
+		switch ds.colors[edge.To.Id] {
+		case undiscovered:
+			if ds.v.TreeEdge != nil {
+				ds.v.TreeEdge(edge)
+			}
+			ds.discover(edge.To)
+			s.Push(&dfsFrame{node: edge.To, edges: ds.a.GetEdges(edge.To), parent: edge, hasParent: true})
+		case discovered:
+			// the edge used to reach the node is seen again from the other side
+			if !ds.directed && f.hasParent && f.parent.Id == edge.Id && f.parent.From.Id == edge.To.Id {
+				continue
+			}
+			if ds.v.BackEdge != nil {
+				ds.v.BackEdge(edge)
+			}
+		case finished:
+			// already reported as a back edge from the descendant
+			if !ds.directed {
+				continue
+			}
+			if ds.times[f.node.Id] < ds.times[edge.To.Id] {
+				if ds.v.ForwardEdge != nil {
+					ds.v.ForwardEdge(edge)
+				}
+			} else if ds.v.CrossEdge != nil {
+				ds.v.CrossEdge(edge)
+			}
+		}
+	}
+}
+
+func (ds *dfsState) discover(node graph.Node) {
+	ds.colors[node.Id] = discovered
+	ds.times[node.Id] = ds.time
+	ds.time++
+	if ds.v.Discover != nil {
+		ds.v.Discover(node)
+	}
+}
+
+// runs a depth first search from start calling the visitor callbacks, edges
+// are followed in the order returned by GetEdges
+func Dfs(a Adjacency, start graph.Node, v DfsVisitor) {
+	newDfsState(a, v).visit(start)
+}
+
+// runs a depth first search from every undiscovered node, in ascending order
+// by id, so every node and edge is visited
+func DfsAll(a Adjacency, v DfsVisitor) {
+	ds := newDfsState(a, v)
+	for _, node := range a.GetAllNodes() {
+		ds.visit(node)
+	}
+}
+
+// returns the nodes ordered so every arc goes from an earlier to a later node
+func TopologicalSort(d *graph.Digraph) ([]graph.Node, error) {
+	order := make([]graph.Node, 0)
+	hasCycle := false
+	DfsAll(d, DfsVisitor{
+		Finish:   func(node graph.Node) { order = append(order, node) },
+		BackEdge: func(graph.Edge) { hasCycle = true },
+	})
+	if hasCycle {
+		return nil, ErrGraphHasCycle
+	}
+	slices.Reverse(order)
+	return order, nil
+}
+
+// returns the strongly connected components, every node in a component can
+// reach the others. Nodes are sorted by id inside each component and the
+// components by their first node
+func StronglyConnectedComponents(d *graph.Digraph) [][]graph.Node {
+	order := make([]graph.Node, 0)
+	DfsAll(d, DfsVisitor{
+		Finish: func(node graph.Node) { order = append(order, node) },
+	})
+
+	// nodes reachable in the reversed graph, starting from the last finished
+	// node, are exactly its component
+	components := make([][]graph.Node, 0)
+	var component []graph.Node
+	ds := newDfsState(d.Reversed(), DfsVisitor{
+		Discover: func(node graph.Node) { component = append(component, node) },
+	})
+	for _, node := range slices.Backward(order) {
+		if ds.colors[node.Id] != undiscovered {
+			continue
+		}
+		component = make([]graph.Node, 0)
+		ds.visit(node)
+		slices.SortFunc(component, func(a, b graph.Node) int {
+			return cmp.Compare(a.Id, b.Id)
+		})
+		components = append(components, component)
+	}
+	slices.SortFunc(components, func(a, b []graph.Node) int {
+		return cmp.Compare(a[0].Id, b[0].Id)
+	})
+	return components
+}
@@ -0,0 +1,156 @@
+package traverse_test
+
+import (
+	"fmt"
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"slices"
+	"testing"
+)
+
+func newDigraph(t *testing.T, nodes []string, arcs [][2]string) *graph.Digraph {
+	t.Helper()
+	d := graph.NewDigraph()
+	for _, id := range nodes {
+		node, _ := graph.NewNode(id)
+		if err := d.AddNode(node); err != nil {
+			t.Fatalf("AddNode(%v) failed: %v", id, err)
+		}
+	}
+	for _, arc := range arcs {
+		from, _ := graph.NewNode(arc[0])
+		to, _ := graph.NewNode(arc[1])
+		if err := d.AddArc(graph.NewEdge(from, to, 1)); err != nil {
+			t.Fatalf("AddArc(%v, %v) failed: %v", arc[0], arc[1], err)
+		}
+	}
+	return d
+}
+
+// returns a graph with a node for each id, and the nodes by id
+func newGraphWithNodes(t *testing.T, ids ...string) (graph.Graph, map[string]graph.Node) {
+	t.Helper()
+	g := graph.NewGraph()
+	nodes := make(map[string]graph.Node)
+	for _, id := range ids {
+		node, err := graph.NewNode(id)
+		if err != nil {
+			t.Fatalf("NewNode(%v) failed: %v", id, err)
+		}
+		if err := g.AddNode(node); err != nil {
+			t.Fatalf("AddNode(%v) failed: %v", id, err)
+		}
+		nodes[id] = node
+	}
+	return g, nodes
+}
+
+func nodeIds(nodes []graph.Node) []string {
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.Id
+	}
+	return ids
+}
+
+func TestDfsUndirected(t *testing.T) {
+	// a 2x2 grid is a single block, one of its four streets closes the cycle
+	g := newGridGraph(2, 2)
+	start, _ := g.GetNode("r0c0")
+	var discovered, finished []string
+	tree, back := 0, 0
+	traverse.Dfs(&g, start, traverse.DfsVisitor{
+		Discover:    func(node graph.Node) { discovered = append(discovered, node.Id) },
+		Finish:      func(node graph.Node) { finished = append(finished, node.Id) },
+		TreeEdge:    func(graph.Edge) { tree++ },
+		BackEdge:    func(graph.Edge) { back++ },
+		ForwardEdge: func(graph.Edge) { t.Fatalf("undirected graphs have no forward edges") },
+		CrossEdge:   func(graph.Edge) { t.Fatalf("undirected graphs have no cross edges") },
+	})
+	if len(discovered) != 4 || len(finished) != 4 {
+		t.Fatalf("Dfs(g, r0c0) discovered %v and finished %v, want every node", discovered, finished)
+	}
+	if discovered[0] != "r0c0" || finished[3] != "r0c0" {
+		t.Fatalf("Dfs(g, r0c0) should discover the start first and finish it last")
+	}
+	if tree != 3 || back != 1 {
+		t.Fatalf("Dfs(g, r0c0) found %v tree and %v back edges, want %v and %v", tree, back, 3, 1)
+	}
+}
+
+func TestDfsDirected(t *testing.T) {
+	d := newDigraph(t, []string{"a", "b", "c", "d"}, [][2]string{
+		{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "a"}, {"d", "b"},
+	})
+	kinds := make(map[string]string)
+	kind := func(name string) func(graph.Edge) {
+		return func(e graph.Edge) { kinds[e.From.Id+e.To.Id] = name }
+	}
+	traverse.DfsAll(d, traverse.DfsVisitor{
+		TreeEdge:    kind("tree"),
+		BackEdge:    kind("back"),
+		ForwardEdge: kind("forward"),
+		CrossEdge:   kind("cross"),
+	})
+	want := map[string]string{"ab": "tree", "bc": "tree", "ca": "back", "ac": "forward", "db": "cross"}
+	for arc, k := range want {
+		if kinds[arc] != k {
+			t.Fatalf("DfsAll(d) classified %v as %q, want %q", arc, kinds[arc], k)
+		}
+	}
+}
+
+func TestDfsDeepGraph(t *testing.T) {
+	// a recursive search would need one call per node
+	g := newGridGraph(1, 100000)
+	start, _ := g.GetNode("r0c0")
+	count := 0
+	traverse.Dfs(&g, start, traverse.DfsVisitor{
+		Discover: func(graph.Node) { count++ },
+	})
+	if count != 100000 {
+		t.Fatalf("Dfs(g, r0c0) discovered %v nodes, want %v", count, 100000)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	d := newDigraph(t, []string{"wake", "shower", "dress", "eat", "leave"}, [][2]string{
+		{"wake", "shower"}, {"shower", "dress"}, {"wake", "eat"}, {"dress", "leave"}, {"eat", "leave"},
+	})
+	order, err := traverse.TopologicalSort(d)
+	if err != nil {
+		t.Fatalf("TopologicalSort(d) failed: %v", err)
+	}
+	ids := nodeIds(order)
+	position := func(id string) int { return slices.Index(ids, id) }
+	for _, node := range d.GetAllNodes() {
+		for _, arc := range d.GetEdges(node) {
+			if position(arc.From.Id) > position(arc.To.Id) {
+				t.Fatalf("TopologicalSort(d) = %v, %v comes after %v", ids, arc.From.Id, arc.To.Id)
+			}
+		}
+	}
+
+	from, _ := graph.NewNode("leave")
+	to, _ := graph.NewNode("wake")
+	_ = d.AddArc(graph.NewEdge(from, to, 1))
+	_, err = traverse.TopologicalSort(d)
+	if err == nil {
+		t.Fatalf("TopologicalSort(d) should fail on a graph with a cycle")
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	d := newDigraph(t, []string{"a", "b", "c", "d", "e", "f"}, [][2]string{
+		{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "d"}, {"e", "f"},
+	})
+	components := traverse.StronglyConnectedComponents(d)
+	got := make([]string, len(components))
+	for i, component := range components {
+		got[i] = fmt.Sprint(nodeIds(component))
+	}
+	want := []string{"[a b c]", "[d e]", "[f]"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("StronglyConnectedComponents(d) = %v, want %v", got, want)
+	}
+}
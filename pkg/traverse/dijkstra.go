@@ -1,118 +1,135 @@
 package traverse
 
 import (
-	"fmt"
+	"graph/pkg/collections"
 	"graph/pkg/graph"
-	"math"
+	"maps"
 	"slices"
 )
 
-type nodeState struct {
-	visited bool
-	value   int
-	prev    graph.Node
+func Dijkstra(g graph.View, a, b graph.Node) (Sequence, error) {
+	return DijkstraWithOptions(g, a, b, Options{})
 }
 
-type dijkstraState struct {
-	nodes map[string]*nodeState
+// same as Dijkstra, reporting to the tracer of the options
+func DijkstraWithOptions(g graph.View, a, b graph.Node, o Options) (Sequence, error) {
+	return shortestPath(g, a, b, o.resolve().Tracer)
 }
 
-func newDijkstraState() dijkstraState {
-	ds := dijkstraState{}
-	ds.nodes = make(map[string]*nodeState)
-	return ds
-}
+// returns the shortest path between a and b, reporting every step to t when it
+// is not nil
+func shortestPath(g graph.View, a, b graph.Node, t Tracer) (Sequence, error) {
+	err := checkReachable(g, a, b)
+	if err != nil {
+		return Sequence{}, err
+	}
+	distances, prev, err := searchShortestPaths(g, a, nil, t)
+	if err != nil {
+		return Sequence{}, err
+	}
 
-func (ds dijkstraState) allNodesVisited() bool {
-	for _, ns := range ds.nodes {
-		if !ns.visited {
-			return false
-		}
+	// go back and reconstruct the sequence
+	s := NewSequence()
+	s.Distance = distances[b.Id]
+	b, _ = g.GetNode(b.Id)
+	s.Sequence = append(s.Sequence, b)
+	for b.Id != a.Id {
+		b, _ = g.GetNode(prev[b.Id].From.Id)
+		s.Sequence = append(s.Sequence, b)
 	}
-	return true
+	slices.Reverse(s.Sequence)
+
+	return s, nil
 }
 
-func Dijkstra(g graph.Graph, a, b graph.Node) (Sequence, error) {
-	// setup initial values
-	ds := newDijkstraState()
-	nodes := g.GetAllNodes()
-	for _, node := range nodes {
-		if _, ok := ds.nodes[node.Id]; !ok {
-			ds.nodes[node.Id] = &nodeState{}
-		}
-		ds.nodes[node.Id].value = math.MaxInt
-	}
-	ds.nodes[a.Id].value = 0
-	ds.nodes[a.Id].visited = true
+type distanceItem struct {
+	node     graph.Node
+	distance int
+}
 
-	x := a
-	for !ds.allNodesVisited() {
-		if _, ok := ds.nodes[x.Id]; !ok {
-			// find a new starting point
-			maxValue := math.MaxInt
-			newNode := graph.Node{}
-			for _, node := range nodes {
-				if ds.nodes[node.Id].visited {
-					continue
-				}
-				if ds.nodes[node.Id].value < maxValue {
-					maxValue = ds.nodes[node.Id].value
-					newNode = node
-				}
-			}
-			x = newNode
-			continue
-		}
+// returns the shortest distance from a to every node reachable from it
+func ShortestDistances(g graph.View, a graph.Node) (map[string]int, error) {
+	distances, _, err := shortestPathTree(g, a)
+	return distances, err
+}
 
-		// update estimates
-		nodesFromX := g.GetNodes(x)
-		for _, y := range nodesFromX {
-			edge, ok := g.GetShortestEdge(x, y)
-			if !ok {
-				return Sequence{}, fmt.Errorf("couldn't get shortest edge between %v and %v", x.Id, y.Id)
-			}
-			xValue := ds.nodes[x.Id].value
-			yValue := ds.nodes[y.Id].value
-			if (xValue + edge.Weight) < yValue {
-				ds.nodes[y.Id].value = xValue + edge.Weight
-				ds.nodes[y.Id].prev = x
-			}
-		}
+// returns the shortest distance from a to every node reachable from it and the
+// edge used to arrive at each node, a has no previous edge
+func shortestPathTree(g graph.View, a graph.Node) (map[string]int, map[string]graph.Edge, error) {
+	return shortestPathTreeWithout(g, a, nil)
+}
 
-		// choose the next node
-		availableNodes := make([]graph.Node, 0)
-		for _, y := range nodesFromX {
-			if !ds.nodes[y.Id].visited {
-				availableNodes = append(availableNodes, y)
-			}
+// same as shortestPathTree, ignoring the edges for which skip returns true
+func shortestPathTreeWithout(g graph.View, a graph.Node, skip func(graph.Edge) bool) (map[string]int, map[string]graph.Edge, error) {
+	return searchShortestPaths(g, a, skip, nil)
+}
+
+// runs dijkstra from a ignoring the edges for which skip returns true, every
+// step is reported to t when it is not nil
+func searchShortestPaths(g graph.View, a graph.Node, skip func(graph.Edge) bool, t Tracer) (map[string]int, map[string]graph.Edge, error) {
+	_, err := g.GetNode(a.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	distances := map[string]int{a.Id: 0}
+	prev := make(map[string]graph.Edge)
+	done := make(map[string]bool)
+	pq := collections.NewPriorityQueue(func(x, y distanceItem) bool {
+		if x.distance != y.distance {
+			return x.distance < y.distance
 		}
-		if len(availableNodes) == 0 {
-			x = ds.nodes[x.Id].prev
+		return x.node.Id < y.node.Id
+	})
+	pq.Push(distanceItem{a, 0})
+	for !pq.Empty() {
+		item, _ := pq.Pop()
+		x := item.node
+		// skip stale entries, x was already settled with a shorter distance
+		if done[x.Id] {
 			continue
 		}
-		slices.SortFunc(availableNodes, func(a, b graph.Node) int {
-			if ds.nodes[a.Id].value < ds.nodes[b.Id].value {
-				return -1
-			} else if ds.nodes[a.Id].value > ds.nodes[b.Id].value {
-				return 1
-			} else {
-				return 0
-			}
+		done[x.Id] = true
+		err := report(t, func() Step {
+			return dijkstraStep(StepNodeVisited, x, graph.Edge{}, item.distance, pq, distances, prev)
 		})
-		y := availableNodes[0]
-		ds.nodes[y.Id].visited = true
-		x = y
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, edge := range g.GetEdges(x) {
+			if skip != nil && skip(edge) {
+				continue
+			}
+			y := edge.To
+			distance := item.distance + edge.Weight
+			if d, ok := distances[y.Id]; !ok || distance < d {
+				distances[y.Id] = distance
+				prev[y.Id] = edge
+				pq.Push(distanceItem{y, distance})
+				err := report(t, func() Step {
+					return dijkstraStep(StepNodeReached, y, edge, distance, pq, distances, prev)
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
 	}
+	return distances, prev, nil
+}
 
-	// go back and reconstruct the sequence
-	s := NewSequence()
-	s.Distance = ds.nodes[b.Id].value
-	s.Sequence = append(s.Sequence, b)
-	for b.Id != a.Id {
-		b = ds.nodes[b.Id].prev
-		s.Sequence = append(s.Sequence, b)
+// returns a step of dijkstra with the nodes still to be settled, each one once,
+// and the edges of the shortest path tree found so far
+func dijkstraStep(kind StepKind, node graph.Node, edge graph.Edge, distance int, pq *collections.PriorityQueue[distanceItem], distances map[string]int, prev map[string]graph.Edge) Step {
+	frontier := make([]graph.Node, 0, pq.Len())
+	queued := make(map[string]bool)
+	for _, item := range pq.Items() {
+		// stale entries are skipped when popped, only the shortest one counts
+		if !queued[item.node.Id] && distances[item.node.Id] == item.distance {
+			queued[item.node.Id] = true
+			frontier = append(frontier, item.node)
+		}
 	}
-	slices.Reverse(s.Sequence)
-
-	return s, nil
+	visited := slices.AppendSeq(make([]graph.Edge, 0, len(prev)), maps.Values(prev))
+	slices.SortFunc(visited, compareEdges)
+	return Step{Kind: kind, Node: node, Edge: edge, Distance: distance, Frontier: frontier, Distances: maps.Clone(distances), Visited: visited}
 }
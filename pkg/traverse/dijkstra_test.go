@@ -0,0 +1,72 @@
+package traverse_test
+
+import (
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+// returns the distance between every pair of nodes with floyd-warshall, used as
+// a reference for the faster searches
+func allDistances(g graph.Graph) map[string]map[string]int {
+	nodes := g.GetAllNodes()
+	d := make(map[string]map[string]int, len(nodes))
+	for _, a := range nodes {
+		d[a.Id] = map[string]int{a.Id: 0}
+		for _, edge := range g.GetEdges(a) {
+			if w, ok := d[a.Id][edge.To.Id]; !ok || edge.Weight < w {
+				d[a.Id][edge.To.Id] = edge.Weight
+			}
+		}
+	}
+	for _, k := range nodes {
+		for _, i := range nodes {
+			ik, ok := d[i.Id][k.Id]
+			if !ok {
+				continue
+			}
+			for j, kj := range d[k.Id] {
+				if ij, ok := d[i.Id][j]; !ok || ik+kj < ij {
+					d[i.Id][j] = ik + kj
+				}
+			}
+		}
+	}
+	return d
+}
+
+func TestDijkstraShortestDistance(t *testing.T) {
+	g, err := graph.NewGraphFromFile("../../graphs/sb.json")
+	if err != nil {
+		t.Fatalf("NewGraphFromFile(sb.json) failed: %v", err)
+	}
+	a, _ := g.GetNode("aa")
+	b, _ := g.GetNode("bv")
+	s, err := traverse.Dijkstra(g, a, b)
+	if err != nil || s.Distance != 374 {
+		t.Fatalf("Dijkstra(aa, bv) = %v, %v, want distance %v", s.Distance, err, 374)
+	}
+
+	// every path is as short as the reference and its edges add up to it
+	distances := allDistances(g)
+	for _, to := range g.GetAllNodes() {
+		s, err := traverse.Dijkstra(g, a, to)
+		if err != nil {
+			t.Fatalf("Dijkstra(aa, %v) failed: %v", to.Id, err)
+		}
+		if want := distances[a.Id][to.Id]; s.Distance != want {
+			t.Fatalf("Dijkstra(aa, %v) distance = %v, want %v", to.Id, s.Distance, want)
+		}
+		total := 0
+		for i := 1; i < len(s.Sequence); i++ {
+			edge, ok := g.GetShortestEdge(s.Sequence[i-1], s.Sequence[i])
+			if !ok {
+				t.Fatalf("Dijkstra(aa, %v) = %v, want a path along edges", to.Id, s.Sequence)
+			}
+			total += edge.Weight
+		}
+		if total != s.Distance {
+			t.Fatalf("Dijkstra(aa, %v) edges add up to %v, want %v", to.Id, total, s.Distance)
+		}
+	}
+}
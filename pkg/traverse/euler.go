@@ -10,7 +10,9 @@ import (
 )
 
 var (
-	ErrGraphNotEulerian = "graph is not eulerian"
+	ErrGraphNotEulerian = errors.New("graph is not eulerian")
+	ErrNoEulerCircuit   = errors.New("couldn't find an euler circuit from the node")
+	ErrNoPairings       = errors.New("no pairings to compare")
 )
 
 type eulerState struct {
@@ -34,7 +36,7 @@ func (es *eulerState) allEdgesVisited() bool {
 	return true
 }
 
-func isEulerianGraph(g graph.Graph) bool {
+func isEulerianGraph(g graph.View) bool {
 	nodes := g.GetAllNodes()
 	for _, node := range nodes {
 		edges := g.GetEdges(node)
@@ -143,9 +145,9 @@ func removeDuplicatePairings(pairings [][]Pair) [][]Pair {
 	return uniquePairings
 }
 
-func getBestPairing(g graph.Graph, pairings [][]Pair) ([]Pair, error) {
+func getBestPairing(g graph.View, pairings [][]Pair, tracer Tracer) ([]Pair, error) {
 	if len(pairings) == 0 {
-		return []Pair{}, errors.New("no pairings to compare")
+		return []Pair{}, ErrNoPairings
 	}
 
 	// keep track of the best pairing so far
@@ -156,12 +158,18 @@ func getBestPairing(g graph.Graph, pairings [][]Pair) ([]Pair, error) {
 		// calculate weight for the pairing
 		pairingWeight := 0
 		for _, pair := range pairing {
-			s, err := Dijkstra(g, pair.L, pair.R)
+			s, err := shortestPath(g, pair.L, pair.R, nil)
 			if err != nil {
 				return []Pair{}, err
 			}
 			pairingWeight += s.Distance
 		}
+		err := report(tracer, func() Step {
+			return Step{Kind: StepPairingEvaluated, Pairing: pairing, Distance: pairingWeight}
+		})
+		if err != nil {
+			return []Pair{}, err
+		}
 
 		// update best pairing if necessary
 		if pairingWeight < bestPairingWeight {
@@ -173,10 +181,10 @@ func getBestPairing(g graph.Graph, pairings [][]Pair) ([]Pair, error) {
 	return pairings[bestPairingId], nil
 }
 
-func duplicateEdges(g *graph.Graph, pairing []Pair) error {
+func duplicateEdges(g *graph.Overlay, pairing []Pair, tracer Tracer) error {
 	for _, pair := range pairing {
 		// get all nodes to connect the pair
-		sequence, err := Dijkstra(*g, pair.L, pair.R)
+		sequence, err := shortestPath(g, pair.L, pair.R, nil)
 		if err != nil {
 			return err
 		}
@@ -187,23 +195,41 @@ func duplicateEdges(g *graph.Graph, pairing []Pair) error {
 			b := sequence.Sequence[i]
 			edge, ok := g.GetShortestEdge(a, b)
 			if !ok {
-				return fmt.Errorf("couldn't get shortest edge between %v and %v", a.Id, b.Id)
+				return fmt.Errorf("%w between %v and %v", graph.ErrEdgeNotPresent, a.Id, b.Id)
 			}
 			newEdge := graph.NewEdge(a, b, edge.Weight)
 			err = g.AddEdge(newEdge)
 			if err != nil {
 				return err
 			}
+			err = report(tracer, func() Step {
+				return Step{Kind: StepEdgeDuplicated, Node: a, Edge: newEdge}
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-func eulerizeGraph(g *graph.Graph) error {
+// returns the graph with the edges needed to make it eulerian added on top, the
+// graph itself is not modified
+func eulerizeGraph(base graph.View, o Options) (*graph.Overlay, error) {
+	tracer := o.Tracer
+	g := graph.NewOverlay(base)
+
 	// duplicate edges of deadend nodes
 	deadendNodes := g.GetAllDeadendNodes()
 	for _, deadendNode := range deadendNodes {
-		g.AddEdge(g.GetEdges(deadendNode)[0])
+		edge := g.GetEdges(deadendNode)[0]
+		g.AddEdge(edge)
+		err := report(tracer, func() Step {
+			return Step{Kind: StepEdgeDuplicated, Node: deadendNode, Edge: edge}
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// build pairs of the odd nodes
@@ -217,35 +243,45 @@ func eulerizeGraph(g *graph.Graph) error {
 	uniquePairings := removeDuplicatePairings(allPairings)
 
 	// get best pairing
-	bestPairing, err := getBestPairing(*g, uniquePairings)
+	bestPairing, err := getBestPairing(g, uniquePairings, tracer)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// duplicate necessary edges
-	err = duplicateEdges(g, bestPairing)
+	err = duplicateEdges(g, bestPairing, tracer)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	g.Print()
+	o.Logger.Debug("eulerized graph",
+		"deadend_nodes", len(deadendNodes),
+		"odd_nodes", len(oddNodes),
+		"pairings", len(uniquePairings),
+		"added_edges", g.EdgeIdLimit()-base.EdgeIdLimit())
 
-	return nil
+	return g, nil
 }
 
-func Euler(g graph.Graph, a graph.Node) (Sequence, error) {
-	// clone to avoid modifying the original graph
-	h := g.Clone()
+func Euler(g graph.View, a graph.Node) (Sequence, error) {
+	return EulerWithOptions(g, a, Options{})
+}
 
-	// check that starting node exists
-	_, err := h.GetNode(a.Id)
+// same as Euler, reporting to the tracer and logger of the options
+func EulerWithOptions(g graph.View, a graph.Node, o Options) (Sequence, error) {
+	o = o.resolve()
+	tracer := o.Tracer
+	// check that starting node exists and all edges can be reached from it
+	err := checkEdgesReachable(g, a)
 	if err != nil {
 		return Sequence{}, err
 	}
 
-	// check if graph is Eulerian
-	if !isEulerianGraph(h) {
-		err := eulerizeGraph(&h)
+	// check if graph is Eulerian, duplicated edges go on an overlay so the
+	// original graph is not modified
+	h := g
+	if !isEulerianGraph(g) {
+		h, err = eulerizeGraph(g, o)
 		if err != nil {
 			return Sequence{}, err
 		}
@@ -258,18 +294,22 @@ func Euler(g graph.Graph, a graph.Node) (Sequence, error) {
 		es.visitedEdges[edge.Key()] = false
 	}
 	st := collections.NewStack[graph.Node]()
+	// edges used to reach each node in the stack after the first one
+	used := collections.NewStack[graph.Edge]()
 
 	// add starting node
 	st.Push(a)
 
 	x := a
+	distance := 0
+	backtracks := 0
 	for !es.allEdgesVisited() {
 		// get valid edges to go to the next node
 		edges := h.GetEdges(x)
 		validEdges := make([]graph.Edge, 0, len(edges))
 		for _, edge := range edges {
 			if !es.visitedEdges[edge.Key()] {
-				key := fmt.Sprintf("%d|%s|%s", st.Len(), x, edge.To)
+				key := fmt.Sprintf("%d|%s", st.Len(), edge.Key())
 				if !es.invalidEdges[key] {
 					validEdges = append(validEdges, edge)
 				}
@@ -278,31 +318,43 @@ func Euler(g graph.Graph, a graph.Node) (Sequence, error) {
 
 		// there's nowhere to go, we need to go back
 		if len(validEdges) == 0 {
+			edge, ok := used.Pop()
+			if !ok {
+				return Sequence{}, &graph.NodeError{Id: a.Id, Err: ErrNoEulerCircuit}
+			}
+			st.Pop()
+			distance -= edge.Weight
+			backtracks++
+			err := report(tracer, func() Step {
+				return eulerStep(StepBacktrack, edge.From, edge, distance, st, used)
+			})
+			if err != nil {
+				return Sequence{}, err
+			}
+
 			// add restriction
-			invalidNode, _ := st.Pop()
-			previousNode, _ := st.Peek()
-			key := fmt.Sprintf("%d|%s|%s", st.Len(), previousNode, invalidNode)
+			key := fmt.Sprintf("%d|%s", st.Len(), edge.Key())
 			es.invalidEdges[key] = true
 
-			// mark the edge as not visited again
-			edge, ok := h.GetShortestEdge(previousNode, invalidNode)
-			if !ok {
-				return Sequence{}, fmt.Errorf("couldn't get shortest edge between %v and %v", previousNode.Id, invalidNode.Id)
-			}
+			// mark the edge as not visited again and go back
 			es.visitedEdges[edge.Key()] = false
-			es.visitedEdges[edge.ReversedEdge().Key()] = false
-
-			// go back
-			x = previousNode
+			x = edge.From
 			continue
 		}
 
 		// add next node
 		nextEdge := validEdges[0]
 		es.visitedEdges[nextEdge.Key()] = true
-		es.visitedEdges[nextEdge.ReversedEdge().Key()] = true
+		used.Push(nextEdge)
+		distance += nextEdge.Weight
 		nextNode := nextEdge.To
 		st.Push(nextNode)
+		err := report(tracer, func() Step {
+			return eulerStep(StepEdgeChosen, x, nextEdge, distance, st, used)
+		})
+		if err != nil {
+			return Sequence{}, err
+		}
 		x = nextNode
 	}
 
@@ -312,15 +364,16 @@ func Euler(g graph.Graph, a graph.Node) (Sequence, error) {
 		lastNode, _ := st.Pop()
 		s.Sequence = append(s.Sequence, lastNode)
 	}
-	for i := 0; i < len(s.Sequence)-1; i++ {
-		a := s.Sequence[i]
-		b := s.Sequence[i+1]
-		edge, ok := h.GetShortestEdge(a, b)
-		if !ok {
-			return Sequence{}, fmt.Errorf("couldn't get shortest edge between %v and %v", a.Id, b.Id)
-		}
-		s.Distance += edge.Weight
-	}
+	s.Distance = distance
+	o.Logger.Debug("found euler circuit", "from", a.Id, "distance", distance, "backtracks", backtracks)
 
 	return s, nil
 }
+
+// returns a step of the euler circuit search with the nodes in the stack and
+// the edges used to get there
+func eulerStep(kind StepKind, node graph.Node, edge graph.Edge, distance int, st *collections.Stack[graph.Node], used *collections.Stack[graph.Edge]) Step {
+	frontier := st.Items()
+	slices.Reverse(frontier)
+	return Step{Kind: kind, Node: node, Edge: edge, Distance: distance, Frontier: frontier, Visited: used.Items()}
+}
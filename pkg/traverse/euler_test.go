@@ -0,0 +1,32 @@
+package traverse_test
+
+import (
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+func TestEulerKeepsGraph(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d")
+	// a path, every edge has to be used twice
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 2))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["d"], 3))
+	limit := g.EdgeIdLimit()
+
+	s, err := traverse.Euler(g, nodes["a"])
+	if err != nil {
+		t.Fatalf("Euler(a) failed: %v", err)
+	}
+	if s.Distance != 12 || len(s.Sequence) != 7 {
+		t.Fatalf("Euler(a) = %v, want %v nodes with distance %v", s, 7, 12)
+	}
+
+	// the duplicated edges were not added to the graph
+	if len(g.GetAllEdges()) != 6 || len(g.GetAllOddNodes()) != 2 {
+		t.Fatalf("Euler(a) modified the graph, it has %v edges", len(g.GetAllEdges())/2)
+	}
+	if g.EdgeIdLimit() != limit {
+		t.Fatalf("Euler(a) used edge ids of the graph, EdgeIdLimit() = %v, want %v", g.EdgeIdLimit(), limit)
+	}
+}
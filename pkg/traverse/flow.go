@@ -0,0 +1,159 @@
+package traverse
+
+import (
+	"errors"
+	"fmt"
+	"graph/pkg/collections"
+	"graph/pkg/graph"
+	"math"
+)
+
+var (
+	ErrSameSourceAndSink = errors.New("source and sink must be different nodes")
+)
+
+// flow going through an edge, the edge is oriented in the direction of the flow
+type EdgeFlow struct {
+	Edge graph.Edge
+	Flow int
+}
+
+type Flow struct {
+	Value int
+	// edges with flow going through them
+	Flows []EdgeFlow
+	// edges of a minimum cut, oriented from the source side
+	Cut []graph.Edge
+	// nodes that can still be reached from the source once the flow is maximum
+	SourceSide []graph.Node
+}
+
+// residual network where each edge is a pair of arcs, arc i^1 goes back through arc i
+type flowNetwork struct {
+	edges    []graph.Edge
+	capacity []int
+	flow     []int
+	arcs     map[string][]int
+}
+
+func newFlowNetwork(g graph.View) flowNetwork {
+	fn := flowNetwork{}
+	fn.edges = uniqueEdgesByWeight(g)
+	fn.capacity = make([]int, 2*len(fn.edges))
+	fn.flow = make([]int, 2*len(fn.edges))
+	fn.arcs = make(map[string][]int)
+	for i, edge := range fn.edges {
+		// streets can be used in both directions up to their capacity
+		fn.capacity[2*i] = edge.Weight
+		fn.capacity[2*i+1] = edge.Weight
+		fn.arcs[edge.From.Id] = append(fn.arcs[edge.From.Id], 2*i)
+		fn.arcs[edge.To.Id] = append(fn.arcs[edge.To.Id], 2*i+1)
+	}
+	return fn
+}
+
+// returns the node an arc arrives to
+func (fn flowNetwork) head(arc int) graph.Node {
+	edge := fn.edges[arc/2]
+	if arc%2 == 0 {
+		return edge.To
+	}
+	return edge.From
+}
+
+func (fn flowNetwork) residual(arc int) int {
+	return fn.capacity[arc] - fn.flow[arc]
+}
+
+// finds the shortest path with residual capacity from source, returns the arc
+// used to arrive at each reached node
+func (fn flowNetwork) bfs(source graph.Node) map[string]int {
+	parents := map[string]int{source.Id: -1}
+	q := collections.NewQueue[graph.Node]()
+	q.Enqueue(source)
+	for !q.Empty() {
+		x, _ := q.Dequeue()
+		for _, arc := range fn.arcs[x.Id] {
+			y := fn.head(arc)
+			if _, ok := parents[y.Id]; !ok && fn.residual(arc) > 0 {
+				parents[y.Id] = arc
+				q.Enqueue(y)
+			}
+		}
+	}
+	return parents
+}
+
+// returns the maximum flow from source to sink using edmonds-karp, edge weights
+// are the capacities of the streets in both directions
+func MaxFlow(g graph.View, source, sink graph.Node) (Flow, error) {
+	if _, err := g.GetNode(source.Id); err != nil {
+		return Flow{}, err
+	}
+	if _, err := g.GetNode(sink.Id); err != nil {
+		return Flow{}, err
+	}
+	if source.Id == sink.Id {
+		return Flow{}, ErrSameSourceAndSink
+	}
+
+	fn := newFlowNetwork(g)
+	f := Flow{}
+	for {
+		parents := fn.bfs(source)
+		if _, ok := parents[sink.Id]; !ok {
+			// the nodes still reached are the source side of a minimum cut
+			for _, node := range g.GetAllNodes() {
+				if _, ok := parents[node.Id]; ok {
+					f.SourceSide = append(f.SourceSide, node)
+				}
+			}
+			break
+		}
+
+		// find the bottleneck of the path and push that much flow through it
+		bottleneck := math.MaxInt
+		for x := sink; x.Id != source.Id; {
+			arc := parents[x.Id]
+			bottleneck = min(bottleneck, fn.residual(arc))
+			x = fn.head(arc ^ 1)
+		}
+		for x := sink; x.Id != source.Id; {
+			arc := parents[x.Id]
+			fn.flow[arc] += bottleneck
+			fn.flow[arc^1] -= bottleneck
+			x = fn.head(arc ^ 1)
+		}
+		f.Value += bottleneck
+	}
+
+	sourceSide := make(map[string]bool)
+	for _, node := range f.SourceSide {
+		sourceSide[node.Id] = true
+	}
+	for i, edge := range fn.edges {
+		if fn.flow[2*i] > 0 {
+			f.Flows = append(f.Flows, EdgeFlow{edge, fn.flow[2*i]})
+		} else if fn.flow[2*i] < 0 {
+			f.Flows = append(f.Flows, EdgeFlow{edge.ReversedEdge(), -fn.flow[2*i]})
+		}
+		if sourceSide[edge.From.Id] && !sourceSide[edge.To.Id] {
+			f.Cut = append(f.Cut, edge)
+		} else if !sourceSide[edge.From.Id] && sourceSide[edge.To.Id] {
+			f.Cut = append(f.Cut, edge.ReversedEdge())
+		}
+	}
+	return f, nil
+}
+
+func (f *Flow) Print() {
+	fmt.Printf("flow: %d\nflows: ", f.Value)
+	for _, ef := range f.Flows {
+		fmt.Printf("%s->%s[%d](%d/%d) ", ef.Edge.From.Id, ef.Edge.To.Id, ef.Edge.Id, ef.Flow, ef.Edge.Weight)
+	}
+	fmt.Print("\ncut: ")
+	for _, edge := range f.Cut {
+		fmt.Printf("%s-%s[%d](%d) ", edge.From.Id, edge.To.Id, edge.Id, edge.Weight)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,60 @@
+package traverse_test
+
+import (
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+func TestMaxFlow(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "s", "a", "b", "c", "t")
+	_ = g.AddEdge(graph.NewEdge(nodes["s"], nodes["a"], 10))
+	_ = g.AddEdge(graph.NewEdge(nodes["s"], nodes["b"], 5))
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 15))
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["c"], 4))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 3))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["t"], 6))
+	// parallel streets add their capacities
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["t"], 2))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["t"], 1))
+
+	f, err := traverse.MaxFlow(g, nodes["s"], nodes["t"])
+	if err != nil {
+		t.Fatalf("MaxFlow(g, s, t) failed: %v", err)
+	}
+	if f.Value != 9 {
+		t.Fatalf("MaxFlow(g, s, t) = %v, want %v", f.Value, 9)
+	}
+
+	// the cut capacity equals the flow
+	capacity := 0
+	for _, edge := range f.Cut {
+		capacity += edge.Weight
+	}
+	if capacity != f.Value {
+		t.Fatalf("MaxFlow(g, s, t) cut capacity = %v, want %v", capacity, f.Value)
+	}
+
+	// flow is conserved at every node except source and sink
+	balance := make(map[string]int)
+	for _, ef := range f.Flows {
+		if ef.Flow > ef.Edge.Weight {
+			t.Fatalf("MaxFlow(g, s, t) sends %v through %v with capacity %v", ef.Flow, ef.Edge.Key(), ef.Edge.Weight)
+		}
+		balance[ef.Edge.From.Id] -= ef.Flow
+		balance[ef.Edge.To.Id] += ef.Flow
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if balance[id] != 0 {
+			t.Fatalf("MaxFlow(g, s, t) balance at %v = %v, want %v", id, balance[id], 0)
+		}
+	}
+	if balance["t"] != f.Value {
+		t.Fatalf("MaxFlow(g, s, t) arriving at t = %v, want %v", balance["t"], f.Value)
+	}
+
+	_, err = traverse.MaxFlow(g, nodes["s"], nodes["s"])
+	if err == nil {
+		t.Fatalf("MaxFlow(g, s, s) should fail, source and sink are the same")
+	}
+}
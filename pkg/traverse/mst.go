@@ -0,0 +1,95 @@
+package traverse
+
+import (
+	"cmp"
+	"graph/pkg/collections"
+	"graph/pkg/graph"
+	"slices"
+)
+
+// returns every edge of the graph once, ordered by weight and then by nodes and id
+func uniqueEdgesByWeight(g graph.View) []graph.Edge {
+	edges := make([]graph.Edge, 0)
+	for _, edge := range g.GetAllEdges() {
+		if edge.From.Id < edge.To.Id {
+			edges = append(edges, edge)
+		}
+	}
+	slices.SortFunc(edges, compareEdges)
+	return edges
+}
+
+// orders edges by weight, ties are broken by nodes and id so results are deterministic
+func compareEdges(a, b graph.Edge) int {
+	return cmp.Or(
+		cmp.Compare(a.Weight, b.Weight),
+		cmp.Compare(a.From.Id, b.From.Id),
+		cmp.Compare(a.To.Id, b.To.Id),
+		cmp.Compare(a.Id, b.Id),
+	)
+}
+
+// returns the minimum spanning tree using kruskal's algorithm and its total weight,
+// a disconnected graph gets a minimum spanning forest
+func Kruskal(g graph.View) (graph.Graph, int, error) {
+	tree := make([]graph.Edge, 0)
+	uf := collections.NewUnionFind[string]()
+	for _, node := range g.GetAllNodes() {
+		uf.Add(node.Id)
+	}
+	total := 0
+	for _, edge := range uniqueEdgesByWeight(g) {
+		if !uf.Union(edge.From.Id, edge.To.Id) {
+			continue
+		}
+		tree = append(tree, edge)
+		total += edge.Weight
+	}
+	return spanningForest(g, tree, total)
+}
+
+// returns the minimum spanning tree using prim's algorithm and its total weight,
+// a disconnected graph gets a minimum spanning forest
+func Prim(g graph.View) (graph.Graph, int, error) {
+	tree := make([]graph.Edge, 0)
+	inTree := make(map[string]bool)
+	total := 0
+	pq := collections.NewPriorityQueue(func(a, b graph.Edge) bool {
+		return compareEdges(a, b) < 0
+	})
+	// start a new tree from each node that is not in the forest yet
+	for _, root := range g.GetAllNodes() {
+		if inTree[root.Id] {
+			continue
+		}
+		inTree[root.Id] = true
+		for _, edge := range g.GetEdges(root) {
+			pq.Push(edge)
+		}
+		for !pq.Empty() {
+			edge, _ := pq.Pop()
+			if inTree[edge.To.Id] {
+				continue
+			}
+			inTree[edge.To.Id] = true
+			tree = append(tree, edge)
+			total += edge.Weight
+			for _, next := range g.GetEdges(edge.To) {
+				if !inTree[next.To.Id] {
+					pq.Push(next)
+				}
+			}
+		}
+	}
+	return spanningForest(g, tree, total)
+}
+
+// returns the graph with the nodes of g and the edges of the tree, which keep
+// their ids so they can be found in g
+func spanningForest(g graph.View, tree []graph.Edge, total int) (graph.Graph, int, error) {
+	forest, err := graph.Subgraph(g, tree)
+	if err != nil {
+		return graph.Graph{}, 0, err
+	}
+	return forest, total, nil
+}
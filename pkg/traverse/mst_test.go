@@ -0,0 +1,48 @@
+package traverse_test
+
+import (
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d", "e", "f")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 4))
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 2))
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["c"], 3))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["d"], 5))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["d"], 7))
+	// e-f is a separate component, so the result is a forest of two trees
+	_ = g.AddEdge(graph.NewEdge(nodes["e"], nodes["f"], 6))
+
+	algorithms := map[string]func(graph.View) (graph.Graph, int, error){
+		"Kruskal": traverse.Kruskal,
+		"Prim":    traverse.Prim,
+	}
+	for name, algorithm := range algorithms {
+		tree, total, err := algorithm(g)
+		if err != nil {
+			t.Fatalf("%s(g) failed: %v", name, err)
+		}
+		if total != 14 {
+			t.Fatalf("%s(g) total = %v, want %v", name, total, 14)
+		}
+		if len(tree.GetAllNodes()) != 6 {
+			t.Fatalf("%s(g) returned %v nodes, want %v", name, len(tree.GetAllNodes()), 6)
+		}
+		if len(tree.GetAllEdges()) != 2*4 {
+			t.Fatalf("%s(g) returned %v edges, want %v", name, len(tree.GetAllEdges())/2, 4)
+		}
+		if len(tree.ConnectedComponents()) != 2 {
+			t.Fatalf("%s(g) should return a forest with %v trees", name, 2)
+		}
+		// the edges of the tree are the same edges of the graph
+		for _, edge := range tree.GetAllEdges() {
+			if found, ok := g.GetEdgeById(edge.Id); !ok || found.Key() != edge.Key() || found.Weight != edge.Weight {
+				t.Fatalf("%s(g) returned the edge %v, want the edge with id %v of the graph %v", name, edge, edge.Id, found)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package traverse
+
+import (
+	"graph/pkg/graph"
+	"math"
+	"slices"
+)
+
+// maximum number of odd nodes for which the best pairing is computed exactly
+const maxExactPairingNodes = 20
+
+// returns a lower bound for the length of a closed route that uses every edge at
+// least once (chinese postman problem), exact indicates that the bound is the
+// length of the optimal route. There is no such route when the edges are in
+// more than one component, the bound is then the sum of the routes of each one
+// and it is never exact
+func PostmanLowerBound(g graph.View) (int, bool, error) {
+	total := 0
+	for _, edge := range g.GetAllEdges() {
+		total += edge.Weight
+	}
+	// every edge is returned in both directions
+	total /= 2
+
+	connected := componentsWithEdges(g) <= 1
+	oddNodes := g.GetAllOddNodes()
+	if len(oddNodes) == 0 {
+		return total, connected, nil
+	}
+
+	// distances between odd nodes, unreachable pairs stay at math.MaxInt
+	n := len(oddNodes)
+	distances := make([][]int, n)
+	for i, node := range oddNodes {
+		fromNode, err := ShortestDistances(g, node)
+		if err != nil {
+			return 0, false, err
+		}
+		distances[i] = make([]int, n)
+		for j, other := range oddNodes {
+			d, ok := fromNode[other.Id]
+			if !ok {
+				d = math.MaxInt
+			}
+			distances[i][j] = d
+		}
+	}
+
+	if n <= maxExactPairingNodes {
+		return total + bestPairingWeight(distances), connected, nil
+	}
+
+	// each odd node is paired at least as far as its nearest odd node, and each pair counts twice
+	nearestSum := 0
+	for i := range oddNodes {
+		nearest := math.MaxInt
+		for j := range oddNodes {
+			if i != j && distances[i][j] < nearest {
+				nearest = distances[i][j]
+			}
+		}
+		nearestSum += nearest
+	}
+	return total + (nearestSum+1)/2, false, nil
+}
+
+// returns the number of components that have at least one edge
+func componentsWithEdges(g graph.View) int {
+	count := 0
+	for _, component := range g.ConnectedComponents() {
+		if slices.ContainsFunc(component, func(node graph.Node) bool {
+			return g.Degree(node) > 0
+		}) {
+			count++
+		}
+	}
+	return count
+}
+
+// returns the minimum total distance to pair all nodes, using dynamic programming over subsets
+func bestPairingWeight(distances [][]int) int {
+	n := len(distances)
+	full := 1<<n - 1
+	best := make([]int, full+1)
+	for mask := range best {
+		best[mask] = math.MaxInt
+	}
+	best[0] = 0
+	for mask := 0; mask < full; mask++ {
+		if best[mask] == math.MaxInt {
+			continue
+		}
+		// the lowest unpaired node must be paired with some other unpaired node
+		i := 0
+		for mask&(1<<i) != 0 {
+			i++
+		}
+		for j := i + 1; j < n; j++ {
+			if mask&(1<<j) != 0 || distances[i][j] == math.MaxInt {
+				continue
+			}
+			next := mask | 1<<i | 1<<j
+			if w := best[mask] + distances[i][j]; w < best[next] {
+				best[next] = w
+			}
+		}
+	}
+	return best[full]
+}
@@ -0,0 +1,153 @@
+package traverse
+
+import (
+	"context"
+	"fmt"
+	"graph/pkg/graph"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// kind of step reported to the tracer
+type StepKind int
+
+const (
+	// an edge was followed, Node is where it starts
+	StepEdgeChosen StepKind = iota
+	// the last followed edge was undone because there was nowhere to go from its end
+	StepBacktrack
+	// the total distance of a pairing of odd nodes was computed
+	StepPairingEvaluated
+	// an edge was duplicated to make the graph eulerian
+	StepEdgeDuplicated
+	// the node was taken from the frontier and its edges are about to be explored
+	StepNodeVisited
+	// the node was reached through Edge, with a new or shorter distance
+	StepNodeReached
+)
+
+func (k StepKind) String() string {
+	switch k {
+	case StepEdgeChosen:
+		return "edge chosen"
+	case StepBacktrack:
+		return "backtrack"
+	case StepPairingEvaluated:
+		return "pairing evaluated"
+	case StepEdgeDuplicated:
+		return "edge duplicated"
+	case StepNodeVisited:
+		return "node visited"
+	case StepNodeReached:
+		return "node reached"
+	}
+	return fmt.Sprintf("step %d", int(k))
+}
+
+// event reported by an algorithm while it runs, fields that don't apply to
+// the kind of step are left empty
+type Step struct {
+	Kind StepKind
+	Node graph.Node
+	Edge graph.Edge
+	// pairs of odd nodes joined by a pairing
+	Pairing []Pair
+	// distance travelled so far, the distance to the node, or the total
+	// distance of the pairing
+	Distance int
+	// nodes waiting to be visited in the order they will be, the priority
+	// queue of dijkstra, the queue of bfs or the stack of euler from its top
+	Frontier []graph.Node
+	// tentative distances of dijkstra or hops of bfs from the start
+	Distances map[string]int
+	// edges followed so far, in the order they were followed by euler and as
+	// the tree found so far by dijkstra and bfs
+	Visited []graph.Edge
+}
+
+func (s Step) String() string {
+	switch s.Kind {
+	case StepPairingEvaluated:
+		pairs := make([]string, 0, len(s.Pairing))
+		for _, pair := range s.Pairing {
+			pairs = append(pairs, pair.Key())
+		}
+		return fmt.Sprintf("%s: %s (%d)", s.Kind, strings.Join(pairs, " "), s.Distance)
+	case StepEdgeChosen, StepBacktrack:
+		return fmt.Sprintf("%s: %s-%s[%d](%d), distance %d", s.Kind, s.Edge.From.Id, s.Edge.To.Id, s.Edge.Id, s.Edge.Weight, s.Distance)
+	case StepNodeVisited:
+		return fmt.Sprintf("%s: %s, distance %d", s.Kind, s.Node.Id, s.Distance)
+	case StepNodeReached:
+		return fmt.Sprintf("%s: %s through %s-%s[%d](%d), distance %d", s.Kind, s.Node.Id, s.Edge.From.Id, s.Edge.To.Id, s.Edge.Id, s.Edge.Weight, s.Distance)
+	}
+	return fmt.Sprintf("%s: %s-%s(%d)", s.Kind, s.Edge.From.Id, s.Edge.To.Id, s.Edge.Weight)
+}
+
+// called by the algorithms for every step they take, a non nil error stops
+// the algorithm, which returns it
+type Tracer func(step Step) error
+
+// where an algorithm reports what it does while it runs, a nil Tracer or
+// Logger falls back to the one set with SetTracer or SetLogger
+type Options struct {
+	Tracer Tracer
+	Logger *slog.Logger
+}
+
+// tracer and logger used when the options of a call leave them empty, they
+// are shared by the whole process so they are swapped atomically
+var defaultTracer atomic.Pointer[Tracer]
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// sets the tracer called by the algorithms run without one of their own, nil
+// disables tracing
+func SetTracer(t Tracer) {
+	if t == nil {
+		defaultTracer.Store(nil)
+		return
+	}
+	defaultTracer.Store(&t)
+}
+
+// handler that drops every record, used so the package is silent by default
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+// sets the logger used by the algorithms run without one of their own, nil
+// makes them silent again
+func SetLogger(l *slog.Logger) {
+	defaultLogger.Store(l)
+}
+
+// returns the options with the defaults in place of what they leave empty,
+// the defaults are read once so a call sees the same ones from start to end
+func (o Options) resolve() Options {
+	if o.Tracer == nil {
+		if t := defaultTracer.Load(); t != nil {
+			o.Tracer = *t
+		}
+	}
+	if o.Logger == nil {
+		o.Logger = defaultLogger.Load()
+	}
+	if o.Logger == nil {
+		o.Logger = discardLogger
+	}
+	return o
+}
+
+// reports the step built by step to t if there is one, the state of the
+// algorithm is only copied when someone is watching
+func report(t Tracer, step func() Step) error {
+	if t == nil {
+		return nil
+	}
+	return t(step())
+}
@@ -0,0 +1,166 @@
+package traverse_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTracer(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d")
+	// a path, the dead ends and the edge between b and c are duplicated
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["c"], 2))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["d"], 3))
+
+	var logs bytes.Buffer
+	traverse.SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { traverse.SetLogger(nil) })
+
+	steps := make(map[traverse.StepKind]int)
+	last := traverse.Step{}
+	traverse.SetTracer(func(step traverse.Step) error {
+		steps[step.Kind]++
+		last = step
+		return nil
+	})
+	t.Cleanup(func() { traverse.SetTracer(nil) })
+
+	s, err := traverse.Euler(g, nodes["a"])
+	if err != nil {
+		t.Fatalf("Euler(a) failed: %v", err)
+	}
+	if steps[traverse.StepEdgeDuplicated] != 3 || steps[traverse.StepPairingEvaluated] != 1 || steps[traverse.StepEdgeChosen] < len(s.Sequence)-1 {
+		t.Fatalf("Euler(a) reported steps %v, want duplicated and chosen edges", steps)
+	}
+	if last.Kind != traverse.StepEdgeChosen || last.Distance != s.Distance {
+		t.Fatalf("Euler(a) last step = %v, want the last edge chosen with distance %v", last, s.Distance)
+	}
+	if !strings.Contains(logs.String(), "eulerized graph") {
+		t.Fatalf("Euler(a) logged %q, want the graph to be eulerized", logs.String())
+	}
+
+	// the error returned by the tracer stops the algorithm
+	stop := errors.New("stop")
+	traverse.SetTracer(func(step traverse.Step) error {
+		if step.Kind == traverse.StepEdgeChosen {
+			return stop
+		}
+		return nil
+	})
+	_, err = traverse.Euler(g, nodes["a"])
+	if !errors.Is(err, stop) {
+		t.Fatalf("Euler(a) error = %v, want the error of the tracer", err)
+	}
+}
+
+func TestTracerShortestPaths(t *testing.T) {
+	g, nodes := newGraphWithNodes(t, "a", "b", "c", "d")
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["b"], 4))
+	_ = g.AddEdge(graph.NewEdge(nodes["a"], nodes["c"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["c"], nodes["b"], 1))
+	_ = g.AddEdge(graph.NewEdge(nodes["b"], nodes["d"], 1))
+
+	steps := make([]traverse.Step, 0)
+	traverse.SetTracer(func(step traverse.Step) error {
+		steps = append(steps, step)
+		return nil
+	})
+	t.Cleanup(func() { traverse.SetTracer(nil) })
+
+	// every node is visited once in order of distance, b is reached twice
+	_, err := traverse.Dijkstra(g, nodes["a"], nodes["d"])
+	if err != nil {
+		t.Fatalf("Dijkstra(a, d) failed: %v", err)
+	}
+	visited := make([]string, 0)
+	reached := 0
+	for _, step := range steps {
+		switch step.Kind {
+		case traverse.StepNodeVisited:
+			visited = append(visited, step.Node.Id)
+		case traverse.StepNodeReached:
+			reached++
+		}
+	}
+	if strings.Join(visited, " ") != "a c b d" || reached != 4 {
+		t.Fatalf("Dijkstra(a, d) visited %v and reached %v nodes, want a c b d and %v", visited, reached, 4)
+	}
+	// after reaching b through c the frontier has no stale entry for b
+	step := steps[4]
+	if step.Node.Id != "b" || step.Distances["b"] != 2 || len(step.Frontier) != 1 || len(step.Visited) != 2 {
+		t.Fatalf("Dijkstra(a, d) step %v = %+v, want b reached with distance %v", 4, step, 2)
+	}
+
+	// bfs reports the nodes still in its queue
+	steps = steps[:0]
+	_, err = traverse.Bfs(g, nodes["a"], nodes["d"])
+	if err != nil {
+		t.Fatalf("Bfs(a, d) failed: %v", err)
+	}
+	step = steps[2]
+	if step.Kind != traverse.StepNodeReached || step.Node.Id != "b" || len(step.Frontier) != 2 || step.Distances["b"] != 1 {
+		t.Fatalf("Bfs(a, d) step %v = %+v, want b reached with c and b in the queue", 2, step)
+	}
+}
+
+func TestTracerConcurrent(t *testing.T) {
+	g := graph.NewGraph()
+	nodes := make([]graph.Node, 0)
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		node, _ := graph.NewNode(id)
+		nodes = append(nodes, node)
+		_ = g.AddNode(node)
+	}
+	for i := 1; i < len(nodes); i++ {
+		_ = g.AddEdge(graph.NewEdge(nodes[i-1], nodes[i], i))
+	}
+	// the default is changed while the traversals run, their own tracers
+	// must still get every step and only their own
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		traverse.SetTracer(func(traverse.Step) error { return nil })
+	}()
+	t.Cleanup(func() { traverse.SetTracer(nil) })
+
+	const runs = 8
+	errs := make(chan error, runs)
+	for i := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start, end := nodes[0], nodes[len(nodes)-1]
+			if i%2 == 1 {
+				start, end = end, start
+			}
+			visited := make([]string, 0)
+			o := traverse.Options{Tracer: func(step traverse.Step) error {
+				if step.Kind == traverse.StepNodeVisited {
+					visited = append(visited, step.Node.Id)
+				}
+				return nil
+			}}
+			_, err := traverse.DijkstraWithOptions(g, start, end, o)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(visited) != len(nodes) || visited[0] != start.Id {
+				errs <- fmt.Errorf("DijkstraWithOptions(%s, %s) visited %v, want every node once from %s", start.Id, end.Id, visited, start.Id)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
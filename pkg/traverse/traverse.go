@@ -8,20 +8,24 @@ import (
 )
 
 var (
-	ErrNoTraverseAlgorithm = "no traverse algorithm has been set"
+	ErrNoTraverseAlgorithm = errors.New("no traverse algorithm has been set")
 )
 
 type Traverser interface {
-	getSequence(g graph.Graph, from graph.Node) (Sequence, error)
+	getSequence(g graph.View, from graph.Node) (Sequence, error)
 }
 
 type TraverseManager struct {
 	traverser Traverser
 }
 
-func (tm *TraverseManager) GetSequence(g graph.Graph, from graph.Node) (Sequence, error) {
+func (tm *TraverseManager) GetSequence(g graph.View, from graph.Node) (Sequence, error) {
 	if tm.traverser == nil {
-		return Sequence{}, errors.New(ErrNoTraverseAlgorithm)
+		return Sequence{}, ErrNoTraverseAlgorithm
+	}
+	err := checkEdgesReachable(g, from)
+	if err != nil {
+		return Sequence{}, err
 	}
 	s, err := tm.traverser.getSequence(g, from)
 	if err != nil {
@@ -30,14 +34,30 @@ func (tm *TraverseManager) GetSequence(g graph.Graph, from graph.Node) (Sequence
 	return s, nil
 }
 
-func (tm *TraverseManager) GetShortestSequence(g graph.Graph) (Sequence, error) {
+func (tm *TraverseManager) GetShortestSequence(g graph.View) (Sequence, error) {
 	if tm.traverser == nil {
-		return Sequence{}, errors.New(ErrNoTraverseAlgorithm)
+		return Sequence{}, ErrNoTraverseAlgorithm
 	}
 	nodes := g.GetAllNodes()
 	s := NewSequence()
 	s.Distance = math.MaxInt
+	// isolated nodes have no streets to start from, unless there are no streets at all
+	starts := make([]graph.Node, 0, len(nodes))
 	for _, node := range nodes {
+		if g.Degree(node) > 0 {
+			starts = append(starts, node)
+		}
+	}
+	if len(starts) == 0 {
+		starts = nodes
+	}
+	if len(starts) > 0 {
+		err := checkEdgesReachable(g, starts[0])
+		if err != nil {
+			return s, err
+		}
+	}
+	for _, node := range starts {
 		sequence, err := tm.traverser.getSequence(g, node)
 		if err != nil {
 			return s, err
@@ -0,0 +1,237 @@
+package traverse
+
+import (
+	"errors"
+	"graph/pkg/graph"
+	"math"
+	"slices"
+)
+
+var (
+	ErrNoNodesToVisit = errors.New("there are no nodes to visit")
+)
+
+// maximum number of nodes for which the tour is computed exactly
+const maxHeldKarpNodes = 12
+
+// shortest paths between every pair of nodes to visit
+type metricClosure struct {
+	nodes []graph.Node
+	paths [][]Sequence
+}
+
+func newMetricClosure(g graph.View, nodes []graph.Node) (metricClosure, error) {
+	mc := metricClosure{}
+	// visiting a node twice doesn't change the tour
+	for _, node := range nodes {
+		if !slices.ContainsFunc(mc.nodes, func(n graph.Node) bool { return n.Id == node.Id }) {
+			mc.nodes = append(mc.nodes, node)
+		}
+	}
+	if len(mc.nodes) == 0 {
+		return metricClosure{}, ErrNoNodesToVisit
+	}
+	mc.paths = make([][]Sequence, len(mc.nodes))
+	for i := range mc.nodes {
+		mc.paths[i] = make([]Sequence, len(mc.nodes))
+	}
+	for i := range mc.nodes {
+		for j := range mc.nodes {
+			if i >= j {
+				continue
+			}
+			s, err := shortestPath(g, mc.nodes[i], mc.nodes[j], nil)
+			if err != nil {
+				return metricClosure{}, err
+			}
+			mc.paths[i][j] = s
+			reversed := NewSequence()
+			reversed.Distance = s.Distance
+			reversed.Sequence = append(reversed.Sequence, s.Sequence...)
+			slices.Reverse(reversed.Sequence)
+			mc.paths[j][i] = reversed
+		}
+	}
+	return mc, nil
+}
+
+func (mc metricClosure) distance(i, j int) int {
+	return mc.paths[i][j].Distance
+}
+
+// returns the length of the closed tour visiting the nodes in the given order
+func (mc metricClosure) tourLength(tour []int) int {
+	length := 0
+	for i := range tour {
+		length += mc.distance(tour[i], tour[(i+1)%len(tour)])
+	}
+	return length
+}
+
+// joins the shortest paths between consecutive nodes of the tour, returning to the first one
+func (mc metricClosure) sequence(tour []int) Sequence {
+	s := NewSequence()
+	s.Sequence = append(s.Sequence, mc.nodes[tour[0]])
+	if len(tour) == 1 {
+		return s
+	}
+	for i := range tour {
+		path := mc.paths[tour[i]][tour[(i+1)%len(tour)]]
+		s.Distance += path.Distance
+		s.Sequence = append(s.Sequence, path.Sequence[1:]...)
+	}
+	return s
+}
+
+// returns a closed sequence through the street network visiting every given node,
+// starting and ending at the first one, small sets are solved exactly
+func TSP(g graph.View, nodes []graph.Node) (Sequence, error) {
+	mc, err := newMetricClosure(g, nodes)
+	if err != nil {
+		return Sequence{}, err
+	}
+	if len(mc.nodes) <= maxHeldKarpNodes {
+		return mc.sequence(heldKarp(mc)), nil
+	}
+	return mc.sequence(improvedNearestNeighbour(mc)), nil
+}
+
+// same as TSP, always using the exact held-karp algorithm, which takes
+// exponential time in the number of nodes
+func HeldKarpTSP(g graph.View, nodes []graph.Node) (Sequence, error) {
+	mc, err := newMetricClosure(g, nodes)
+	if err != nil {
+		return Sequence{}, err
+	}
+	return mc.sequence(heldKarp(mc)), nil
+}
+
+// same as TSP, always using nearest neighbour improved with 2-opt and or-opt
+func HeuristicTSP(g graph.View, nodes []graph.Node) (Sequence, error) {
+	mc, err := newMetricClosure(g, nodes)
+	if err != nil {
+		return Sequence{}, err
+	}
+	return mc.sequence(improvedNearestNeighbour(mc)), nil
+}
+
+// returns the optimal tour starting at node 0 using dynamic programming over subsets
+func heldKarp(mc metricClosure) []int {
+	n := len(mc.nodes)
+	if n == 1 {
+		return []int{0}
+	}
+	// best[mask][j] is the length of the shortest path from 0 visiting mask and ending at j
+	full := 1<<n - 1
+	best := make([][]int, full+1)
+	prev := make([][]int, full+1)
+	for mask := range best {
+		best[mask] = make([]int, n)
+		prev[mask] = make([]int, n)
+		for j := range best[mask] {
+			best[mask][j] = math.MaxInt
+		}
+	}
+	best[1][0] = 0
+	for mask := 1; mask <= full; mask += 2 {
+		for j := 0; j < n; j++ {
+			if best[mask][j] == math.MaxInt {
+				continue
+			}
+			for k := 1; k < n; k++ {
+				if mask&(1<<k) != 0 {
+					continue
+				}
+				next := mask | 1<<k
+				if length := best[mask][j] + mc.distance(j, k); length < best[next][k] {
+					best[next][k] = length
+					prev[next][k] = j
+				}
+			}
+		}
+	}
+
+	// choose the last node before returning to 0 and go back
+	last := 1
+	for j := 2; j < n; j++ {
+		if best[full][j]+mc.distance(j, 0) < best[full][last]+mc.distance(last, 0) {
+			last = j
+		}
+	}
+	tour := make([]int, 0, n)
+	mask := full
+	for last != 0 {
+		tour = append(tour, last)
+		last, mask = prev[mask][last], mask&^(1<<last)
+	}
+	tour = append(tour, 0)
+	slices.Reverse(tour)
+	return tour
+}
+
+// returns a tour starting at node 0 built by always going to the nearest
+// unvisited node, then improved with 2-opt and or-opt until neither helps
+func improvedNearestNeighbour(mc metricClosure) []int {
+	n := len(mc.nodes)
+	visited := make([]bool, n)
+	visited[0] = true
+	tour := []int{0}
+	for len(tour) < n {
+		last := tour[len(tour)-1]
+		next := -1
+		for j := 0; j < n; j++ {
+			if !visited[j] && (next == -1 || mc.distance(last, j) < mc.distance(last, next)) {
+				next = j
+			}
+		}
+		visited[next] = true
+		tour = append(tour, next)
+	}
+	for twoOpt(mc, tour) || orOpt(mc, tour) {
+	}
+	return tour
+}
+
+// reverses the first segment that shortens the tour, indicates if it found one
+func twoOpt(mc metricClosure, tour []int) bool {
+	n := len(tour)
+	for i := 1; i < n-1; i++ {
+		for j := i + 1; j < n; j++ {
+			a, b := tour[i-1], tour[i]
+			c, d := tour[j], tour[(j+1)%n]
+			if mc.distance(a, c)+mc.distance(b, d) < mc.distance(a, b)+mc.distance(c, d) {
+				slices.Reverse(tour[i : j+1])
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// moves the first segment of up to three nodes that shortens the tour when
+// placed somewhere else, indicates if it found one
+func orOpt(mc metricClosure, tour []int) bool {
+	n := len(tour)
+	for size := 1; size <= 3 && size < n-1; size++ {
+		// the first node stays in place so the tour keeps its start
+		for i := 1; i+size <= n; i++ {
+			first, last := tour[i], tour[i+size-1]
+			prev, next := tour[i-1], tour[(i+size)%n]
+			gain := mc.distance(prev, first) + mc.distance(last, next) - mc.distance(prev, next)
+			rest := slices.Concat(tour[:i], tour[i+size:])
+			for p := 1; p <= len(rest); p++ {
+				if p == i {
+					continue
+				}
+				x, y := rest[p-1], rest[p%len(rest)]
+				cost := mc.distance(x, first) + mc.distance(last, y) - mc.distance(x, y)
+				if cost < gain {
+					segment := slices.Clone(tour[i : i+size])
+					copy(tour, slices.Concat(rest[:p], segment, rest[p:]))
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
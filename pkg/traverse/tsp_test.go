@@ -0,0 +1,116 @@
+package traverse_test
+
+import (
+	"fmt"
+	"graph/pkg/graph"
+	"graph/pkg/traverse"
+	"testing"
+)
+
+// a grid of streets with rows*cols intersections named r<row>c<col>
+func newGridGraph(rows, cols int) graph.Graph {
+	g := graph.NewGraph()
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			node, _ := graph.NewNode(fmt.Sprintf("r%dc%d", r, c))
+			_ = g.AddNode(node)
+			if r > 0 {
+				up, _ := g.GetNode(fmt.Sprintf("r%dc%d", r-1, c))
+				_ = g.AddEdge(graph.NewEdge(up, node, 2))
+			}
+			if c > 0 {
+				left, _ := g.GetNode(fmt.Sprintf("r%dc%d", r, c-1))
+				_ = g.AddEdge(graph.NewEdge(left, node, 1))
+			}
+		}
+	}
+	return g
+}
+
+func TestTSP(t *testing.T) {
+	g := newGridGraph(4, 4)
+	corners := make([]graph.Node, 0)
+	for _, id := range []string{"r0c0", "r3c3", "r0c3", "r3c0"} {
+		node, _ := g.GetNode(id)
+		corners = append(corners, node)
+	}
+
+	// going around the grid is the only optimal tour through the four corners
+	solvers := map[string]func(graph.View, []graph.Node) (traverse.Sequence, error){
+		"TSP":          traverse.TSP,
+		"HeldKarpTSP":  traverse.HeldKarpTSP,
+		"HeuristicTSP": traverse.HeuristicTSP,
+	}
+	for name, solver := range solvers {
+		s, err := solver(g, corners)
+		if err != nil {
+			t.Fatalf("%s(g, corners) failed: %v", name, err)
+		}
+		if s.Distance != 18 {
+			t.Fatalf("%s(g, corners) distance = %v, want %v", name, s.Distance, 18)
+		}
+		first, last := s.Sequence[0], s.Sequence[len(s.Sequence)-1]
+		if first.Id != "r0c0" || last.Id != "r0c0" {
+			t.Fatalf("%s(g, corners) should start and end at r0c0, got %v and %v", name, first.Id, last.Id)
+		}
+		// consecutive nodes must be joined by streets
+		for i := 1; i < len(s.Sequence); i++ {
+			if _, ok := g.GetShortestEdge(s.Sequence[i-1], s.Sequence[i]); !ok {
+				t.Fatalf("%s(g, corners) jumps from %v to %v", name, s.Sequence[i-1].Id, s.Sequence[i].Id)
+			}
+		}
+	}
+}
+
+func TestHeuristicTSP(t *testing.T) {
+	g := newGridGraph(5, 5)
+	nodes := g.GetAllNodes()
+	exact, err := traverse.HeldKarpTSP(g, nodes[:10])
+	if err != nil {
+		t.Fatalf("HeldKarpTSP(g, nodes) failed: %v", err)
+	}
+	heuristic, err := traverse.HeuristicTSP(g, nodes[:10])
+	if err != nil {
+		t.Fatalf("HeuristicTSP(g, nodes) failed: %v", err)
+	}
+	if heuristic.Distance < exact.Distance {
+		t.Fatalf("HeuristicTSP() = %v, shorter than the optimal %v", heuristic.Distance, exact.Distance)
+	}
+
+	// every node is visited
+	s, err := traverse.TSP(g, nodes)
+	if err != nil {
+		t.Fatalf("TSP(g, nodes) failed: %v", err)
+	}
+	visited := make(map[string]bool)
+	for _, node := range s.Sequence {
+		visited[node.Id] = true
+	}
+	if len(visited) != len(nodes) {
+		t.Fatalf("TSP(g, nodes) visited %v nodes, want %v", len(visited), len(nodes))
+	}
+}
+
+func TestTSPNotTraced(t *testing.T) {
+	g := newGridGraph(3, 3)
+	nodes := make([]graph.Node, 0)
+	for _, id := range []string{"r0c0", "r2c2", "r0c2"} {
+		node, _ := g.GetNode(id)
+		nodes = append(nodes, node)
+	}
+	steps := 0
+	traverse.SetTracer(func(step traverse.Step) error {
+		steps++
+		return nil
+	})
+	t.Cleanup(func() { traverse.SetTracer(nil) })
+
+	// the shortest paths between the nodes are internal to the tour
+	_, err := traverse.TSP(g, nodes)
+	if err != nil {
+		t.Fatalf("TSP() failed: %v", err)
+	}
+	if steps != 0 {
+		t.Fatalf("TSP() reported %v steps, want %v", steps, 0)
+	}
+}
@@ -0,0 +1,414 @@
+package tui
+
+import (
+	"fmt"
+	"graph/pkg/graph"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type pane int
+
+const (
+	nodesPane pane = iota
+	edgesPane
+)
+
+type mode int
+
+const (
+	normalMode mode = iota
+	// typing a command in the command line
+	commandMode
+	// typing the new weight of the selected edge
+	weightMode
+)
+
+// full screen editor state, keys change it and Render draws it. Changes are
+// applied through the history so they can be undone like in the menus
+type Editor struct {
+	history *graph.History
+	focus   pane
+	mode    mode
+	// selected node in ascending order by id and selected edge of that node
+	node int
+	edge int
+	// text typed in the command line or the weight being edited
+	input []rune
+	// result of the last command
+	message string
+	done    bool
+}
+
+// returns an editor for the graph of the history
+func NewEditor(h *graph.History) *Editor {
+	e := Editor{}
+	e.history = h
+	e.input = make([]rune, 0)
+	e.message = "press : to type a command, ? lists them"
+	return &e
+}
+
+// indicates if the user asked to leave the editor
+func (e *Editor) Done() bool {
+	return e.done
+}
+
+func (e *Editor) graph() *graph.Graph {
+	return e.history.Graph()
+}
+
+// returns the selected node, indicates if there is one
+func (e *Editor) selectedNode() (graph.Node, bool) {
+	nodes := e.graph().GetAllNodes()
+	if len(nodes) == 0 {
+		return graph.Node{}, false
+	}
+	e.node = min(max(e.node, 0), len(nodes)-1)
+	return nodes[e.node], true
+}
+
+// returns the edges of the selected node
+func (e *Editor) selectedEdges() []graph.Edge {
+	node, ok := e.selectedNode()
+	if !ok {
+		return []graph.Edge{}
+	}
+	return e.graph().GetEdges(node)
+}
+
+// returns the selected edge, indicates if there is one
+func (e *Editor) selectedEdge() (graph.Edge, bool) {
+	edges := e.selectedEdges()
+	if len(edges) == 0 {
+		return graph.Edge{}, false
+	}
+	e.edge = min(max(e.edge, 0), len(edges)-1)
+	return edges[e.edge], true
+}
+
+// selects the node with given id, indicates if it exists
+func (e *Editor) selectNode(id string) bool {
+	i := slices.IndexFunc(e.graph().GetAllNodes(), func(node graph.Node) bool {
+		return node.Id == id
+	})
+	if i < 0 {
+		return false
+	}
+	e.node = i
+	e.edge = 0
+	return true
+}
+
+// applies the key to the editor
+func (e *Editor) HandleKey(k Key) {
+	if k.Code == KeyCtrlC {
+		e.done = true
+		return
+	}
+	switch e.mode {
+	case commandMode:
+		e.handleCommandKey(k)
+	case weightMode:
+		e.handleWeightKey(k)
+	default:
+		e.handleNormalKey(k)
+	}
+}
+
+func (e *Editor) handleNormalKey(k Key) {
+	switch {
+	case k.Code == KeyUp || k == RuneKey('k'):
+		e.move(-1)
+	case k.Code == KeyDown || k == RuneKey('j'):
+		e.move(1)
+	case k.Code == KeyLeft || k == RuneKey('h'):
+		e.focus = nodesPane
+	case k.Code == KeyRight || k == RuneKey('l'):
+		if len(e.selectedEdges()) > 0 {
+			e.focus = edgesPane
+		}
+	case k.Code == KeyTab:
+		if e.focus == edgesPane {
+			e.focus = nodesPane
+		} else if len(e.selectedEdges()) > 0 {
+			e.focus = edgesPane
+		}
+	case k == RuneKey(':'):
+		e.mode = commandMode
+		e.input = e.input[:0]
+	case k == RuneKey('w') || (k.Code == KeyEnter && e.focus == edgesPane):
+		edge, ok := e.selectedEdge()
+		if !ok {
+			e.message = "select an edge to edit its weight"
+			return
+		}
+		e.focus = edgesPane
+		e.mode = weightMode
+		e.input = []rune(strconv.Itoa(edge.Weight))
+	case k == RuneKey('d'):
+		e.deleteSelected()
+	case k == RuneKey('u'):
+		e.run("u")
+	case k == RuneKey('r'):
+		e.run("r")
+	case k == RuneKey('?'):
+		e.run("?")
+	case k == RuneKey('q'):
+		e.done = true
+	}
+}
+
+// moves the selection of the focused pane
+func (e *Editor) move(delta int) {
+	if e.focus == edgesPane {
+		e.edge = min(max(e.edge+delta, 0), max(len(e.selectedEdges())-1, 0))
+		return
+	}
+	e.node = min(max(e.node+delta, 0), max(len(e.graph().GetAllNodes())-1, 0))
+	e.edge = 0
+}
+
+// removes the selected edge, or the selected node with all its edges
+func (e *Editor) deleteSelected() {
+	if e.focus == edgesPane {
+		edge, ok := e.selectedEdge()
+		if ok {
+			e.run(fmt.Sprintf("er %d", edge.Id))
+		}
+		return
+	}
+	node, ok := e.selectedNode()
+	if ok {
+		e.run("nr " + node.Id)
+	}
+}
+
+func (e *Editor) handleCommandKey(k Key) {
+	switch k.Code {
+	case KeyEnter:
+		e.mode = normalMode
+		e.run(string(e.input))
+		e.input = e.input[:0]
+	case KeyEscape:
+		e.mode = normalMode
+		e.input = e.input[:0]
+	case KeyBackspace:
+		if len(e.input) > 0 {
+			e.input = e.input[:len(e.input)-1]
+		}
+	case KeyTab:
+		e.complete()
+	case KeyRune:
+		e.input = append(e.input, k.Rune)
+	}
+}
+
+func (e *Editor) handleWeightKey(k Key) {
+	switch k.Code {
+	case KeyEnter:
+		e.mode = normalMode
+		edge, ok := e.selectedEdge()
+		if ok {
+			e.run(fmt.Sprintf("w %d %s", edge.Id, string(e.input)))
+		}
+		e.input = e.input[:0]
+	case KeyEscape:
+		e.mode = normalMode
+		e.input = e.input[:0]
+	case KeyBackspace:
+		if len(e.input) > 0 {
+			e.input = e.input[:len(e.input)-1]
+		}
+	case KeyRune:
+		// the weight is checked when it is set, a minus sign can only lead
+		if '0' <= k.Rune && k.Rune <= '9' || k.Rune == '-' && len(e.input) == 0 {
+			e.input = append(e.input, k.Rune)
+		}
+	}
+}
+
+// completes the last word of the command line with the node ids starting
+// with it, as far as they share a prefix
+func (e *Editor) complete() {
+	line := string(e.input)
+	start := strings.LastIndex(line, " ") + 1
+	if start == 0 {
+		// the first word is the command
+		return
+	}
+	prefix := line[start:]
+	matches := make([]string, 0)
+	for _, node := range e.graph().GetAllNodes() {
+		if strings.HasPrefix(node.Id, prefix) {
+			matches = append(matches, node.Id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		e.message = fmt.Sprintf("no node starts with %q", prefix)
+	case 1:
+		e.input = []rune(line[:start] + matches[0] + " ")
+	default:
+		common := []rune(matches[0])
+		for _, id := range matches[1:] {
+			for !strings.HasPrefix(id, string(common)) {
+				common = common[:len(common)-1]
+			}
+		}
+		e.input = []rune(line[:start] + string(common))
+		e.message = strings.Join(matches, " ")
+	}
+}
+
+var commandHelp = []string{
+	"n <id>...: add nodes",
+	"nr <id>: remove node",
+	"e <from> <to> <weight>: add edge",
+	"er <edge id>: remove edge",
+	"w <edge id> <weight>: set edge weight",
+	"g <id>: go to node",
+	"u: undo",
+	"r: redo",
+	"q: quit",
+}
+
+// runs a command typed in the command line and leaves its result as message
+func (e *Editor) run(command string) {
+	err := e.runCommand(strings.Fields(command))
+	if err != nil {
+		e.message = fmt.Sprintf("error: %s", err.Error())
+	}
+}
+
+func (e *Editor) runCommand(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	h := e.history
+	g := e.graph()
+	switch args[0] {
+	case "n":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: %s", commandHelp[0])
+		}
+		// every id is checked before adding any, so the nodes are added and
+		// undone together
+		nodes := make([]graph.Node, 0, len(args)-1)
+		for _, id := range args[1:] {
+			node, err := graph.NewNode(id)
+			if err != nil {
+				return err
+			}
+			nodes = append(nodes, node)
+		}
+		err := h.AddNodes(nodes)
+		if err != nil {
+			return err
+		}
+		e.selectNode(nodes[len(nodes)-1].Id)
+		e.message = fmt.Sprintf("added %s", strings.Join(args[1:], " "))
+	case "nr":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: %s", commandHelp[1])
+		}
+		node, err := g.GetNode(args[1])
+		if err != nil {
+			return err
+		}
+		h.RemoveNode(node)
+		e.focus = nodesPane
+		e.message = fmt.Sprintf("removed %s", node.Id)
+	case "e":
+		if len(args) != 4 {
+			return fmt.Errorf("usage: %s", commandHelp[2])
+		}
+		from, err := g.GetNode(args[1])
+		if err != nil {
+			return err
+		}
+		to, err := g.GetNode(args[2])
+		if err != nil {
+			return err
+		}
+		weight, err := strconv.Atoi(args[3])
+		if err != nil {
+			return err
+		}
+		err = h.AddEdge(graph.NewEdge(from, to, weight))
+		if err != nil {
+			return err
+		}
+		e.message = fmt.Sprintf("added edge %s-%s(%d)", from.Id, to.Id, weight)
+	case "er":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: %s", commandHelp[3])
+		}
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return err
+		}
+		err = h.RemoveEdgeById(id)
+		if err != nil {
+			return err
+		}
+		if len(e.selectedEdges()) == 0 {
+			e.focus = nodesPane
+		}
+		e.message = fmt.Sprintf("removed edge %d", id)
+	case "w":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: %s", commandHelp[4])
+		}
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return err
+		}
+		weight, err := strconv.Atoi(args[2])
+		if err != nil {
+			return err
+		}
+		edge, ok := g.GetEdgeById(id)
+		if !ok {
+			return &graph.EdgeError{Id: id, Err: graph.ErrEdgeNotPresent}
+		}
+		edge.Weight = weight
+		err = h.UpdateEdge(edge)
+		if err != nil {
+			return err
+		}
+		e.message = fmt.Sprintf("edge %d weight set to %d", id, weight)
+	case "g":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: %s", commandHelp[5])
+		}
+		if !e.selectNode(args[1]) {
+			_, err := g.GetNode(args[1])
+			return err
+		}
+		e.focus = nodesPane
+		e.message = ""
+	case "u":
+		name, ok := h.Undo()
+		if !ok {
+			e.message = "nothing to undo"
+			return nil
+		}
+		e.message = fmt.Sprintf("undone: %s", name)
+	case "r":
+		name, ok := h.Redo()
+		if !ok {
+			e.message = "nothing to redo"
+			return nil
+		}
+		e.message = fmt.Sprintf("redone: %s", name)
+	case "q":
+		e.done = true
+	case "?", "help":
+		e.message = strings.Join(commandHelp, ", ")
+	default:
+		return fmt.Errorf("unknown command %s, ? lists them", args[0])
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package tui_test
+
+import (
+	"graph/pkg/graph"
+	"graph/pkg/tui"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// types the text as keys, newlines press enter and tabs press tab
+func typeText(e *tui.Editor, text string) {
+	for _, k := range tui.ParseKeys([]byte(text)) {
+		e.HandleKey(k)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	keys := tui.ParseKeys([]byte("a\x1b[A\x1b[B\tñ\r\x7f\x1b\x03"))
+	expected := []tui.Key{
+		tui.RuneKey('a'), {Code: tui.KeyUp}, {Code: tui.KeyDown}, {Code: tui.KeyTab}, tui.RuneKey('ñ'),
+		{Code: tui.KeyEnter}, {Code: tui.KeyBackspace}, {Code: tui.KeyEscape}, {Code: tui.KeyCtrlC},
+	}
+	if len(keys) != len(expected) {
+		t.Fatalf("ParseKeys() = %v, want %v", keys, expected)
+	}
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Fatalf("ParseKeys() = %v, want %v", keys, expected)
+		}
+	}
+}
+
+func TestParseKeysPartial(t *testing.T) {
+	// a char split between two reads is parsed once both are joined
+	b := []byte("añ🙂")
+	keys, rest := tui.ParseKeysPartial(b[:2])
+	if len(keys) != 1 || keys[0] != tui.RuneKey('a') || string(rest) != string(b[1:2]) {
+		t.Fatalf("ParseKeysPartial(%q) = %v, %q, want a and the start of ñ", b[:2], keys, rest)
+	}
+	keys, rest = tui.ParseKeysPartial(append(rest, b[2:5]...))
+	if len(keys) != 1 || keys[0] != tui.RuneKey('ñ') || len(rest) != 2 {
+		t.Fatalf("ParseKeysPartial() = %v, %q, want ñ and the start of 🙂", keys, rest)
+	}
+	keys, rest = tui.ParseKeysPartial(append(rest, b[5:]...))
+	if len(keys) != 1 || keys[0] != tui.RuneKey('🙂') || len(rest) != 0 {
+		t.Fatalf("ParseKeysPartial() = %v, %q, want 🙂 and nothing left", keys, rest)
+	}
+
+	// so is an escape sequence, including its parameters
+	for _, split := range []string{"\x1b", "\x1b[", "\x1b[1;"} {
+		keys, rest = tui.ParseKeysPartial([]byte("a" + split))
+		if len(keys) != 1 || keys[0] != tui.RuneKey('a') || string(rest) != split {
+			t.Fatalf("ParseKeysPartial(%q) = %v, %q, want a and %q", "a"+split, keys, rest, split)
+		}
+	}
+	keys, rest = tui.ParseKeysPartial(append([]byte("\x1b"), "[A"...))
+	if len(keys) != 1 || keys[0].Code != tui.KeyUp || len(rest) != 0 {
+		t.Fatalf("ParseKeysPartial(%q) = %v, %q, want the up key", "\x1b[A", keys, rest)
+	}
+	if keys := tui.ParseKeys([]byte("\x1b")); len(keys) != 1 || keys[0].Code != tui.KeyEscape {
+		t.Fatalf("ParseKeys(%q) = %v, want the escape key", "\x1b", keys)
+	}
+}
+
+func TestEditor(t *testing.T) {
+	g := graph.NewGraph()
+	h := graph.NewHistory(&g)
+	e := tui.NewEditor(h)
+
+	typeText(e, ":n santiago valparaiso vina\r")
+	typeText(e, ":e sa\tval\t5\r")
+	if len(g.GetAllNodes()) != 3 || len(g.GetAllEdges()) != 2 {
+		t.Fatalf("commands added %v nodes and %v edges, want %v and %v", len(g.GetAllNodes()), len(g.GetAllEdges())/2, 3, 1)
+	}
+	edge, ok := g.GetEdgeById(0)
+	if !ok || edge.Weight != 5 {
+		t.Fatalf("GetEdgeById(0) = %v, %v, want the edge added with completed ids", edge, ok)
+	}
+
+	// ids sharing a prefix are completed as far as they match
+	typeText(e, ":g v\t")
+	screen := strings.Join(e.Render(80, 12), "\n")
+	if !strings.Contains(screen, ":g v_") || !strings.Contains(screen, "valparaiso vina") {
+		t.Fatalf("Render() after completing v =\n%s\nwant both candidates", screen)
+	}
+	typeText(e, "\x1b")
+
+	// select santiago, move to its edges and edit the weight inline
+	typeText(e, ":g santiago\r\x1b[C")
+	typeText(e, "w\x7f12\r")
+	if edge, _ := g.GetEdgeById(0); edge.Weight != 12 {
+		t.Fatalf("inline edit set weight %v, want %v", edge.Weight, 12)
+	}
+	lines := e.Render(60, 10)
+	if len(lines) != 10 {
+		t.Fatalf("Render(60, 10) returned %v lines, want %v", len(lines), 10)
+	}
+	for _, line := range lines {
+		if utf8.RuneCountInString(line) != 60 {
+			t.Fatalf("Render(60, 10) line %q is not %v chars wide", line, 60)
+		}
+	}
+	screen = strings.Join(lines, "\n")
+	if !strings.Contains(screen, "nodes 3  edges 1  weight 12  components 2  odd nodes 2") {
+		t.Fatalf("Render() =\n%s\nwant the updated stats", screen)
+	}
+	if !strings.Contains(screen, "- santiago (1)") || !strings.Contains(screen, "> valparaiso [0] (12)") {
+		t.Fatalf("Render() =\n%s\nwant santiago selected and its edge focused", screen)
+	}
+
+	// changes go through the history
+	typeText(e, "u")
+	if edge, _ := g.GetEdgeById(0); edge.Weight != 5 {
+		t.Fatalf("undo after the inline edit left weight %v, want %v", edge.Weight, 5)
+	}
+	// a minus sign is only taken in front of the weight
+	typeText(e, "w\x7f-4-\r")
+	if edge, _ := g.GetEdgeById(0); edge.Weight != -4 {
+		t.Fatalf("inline edit set weight %v, want %v", edge.Weight, -4)
+	}
+	typeText(e, "u")
+	typeText(e, "d")
+	if len(g.GetAllEdges()) != 0 {
+		t.Fatalf("d on the focused edge didn't remove it")
+	}
+	typeText(e, "d")
+	if _, err := g.GetNode("santiago"); err == nil {
+		t.Fatalf("d on the selected node didn't remove it")
+	}
+
+	typeText(e, ":x\r")
+	if screen := strings.Join(e.Render(80, 12), "\n"); !strings.Contains(screen, "error: unknown command x") {
+		t.Fatalf("Render() =\n%s\nwant an error for the unknown command", screen)
+	}
+	// nodes added together are checked and undone together
+	typeText(e, ":n x vina\r")
+	if _, err := g.GetNode("x"); err == nil {
+		t.Fatalf(":n x vina added x even though vina exists")
+	}
+	typeText(e, ":n x y\ru")
+	if len(g.GetAllNodes()) != 2 {
+		t.Fatalf("undo after :n x y left %v nodes, want %v", len(g.GetAllNodes()), 2)
+	}
+	typeText(e, "q")
+	if !e.Done() {
+		t.Fatalf("q should leave the editor")
+	}
+}
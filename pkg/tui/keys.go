@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"bytes"
+	"slices"
+	"unicode/utf8"
+)
+
+type KeyCode int
+
+const (
+	// a printable char, stored in Key.Rune
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyEscape
+	KeyCtrlC
+)
+
+// key pressed by the user, Rune is only set for KeyRune
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// returns the key for a printable char
+func RuneKey(r rune) Key {
+	return Key{Code: KeyRune, Rune: r}
+}
+
+// returns the keys in the bytes read from a terminal in raw mode, unknown
+// escape sequences and control chars are dropped
+func ParseKeys(b []byte) []Key {
+	return parseKeys(b)
+}
+
+// same as ParseKeys, but a char or escape sequence cut at the end of the bytes
+// is returned instead of parsed, so it can be parsed again with the bytes of
+// the next read. A lone escape is returned too, it is the escape key only if
+// nothing follows it
+func ParseKeysPartial(b []byte) ([]Key, []byte) {
+	b, rest := splitIncompleteEscape(b)
+	if len(rest) == 0 {
+		b, rest = splitIncompleteRune(b)
+	}
+	return parseKeys(b), rest
+}
+
+// splits the bytes before the last escape sequence if it has no final byte yet
+func splitIncompleteEscape(b []byte) ([]byte, []byte) {
+	i := bytes.LastIndexByte(b, 0x1b)
+	if i < 0 {
+		return b, nil
+	}
+	seq := b[i:]
+	switch {
+	case len(seq) == 1:
+	case seq[1] == 'O' && len(seq) == 2:
+	case seq[1] == '[' && !slices.ContainsFunc(seq[2:], func(c byte) bool { return c < 0x20 || c > 0x3f }):
+		// only parameters so far
+	default:
+		return b, nil
+	}
+	return b[:i], seq
+}
+
+// splits the bytes before the last rune if it is missing bytes
+func splitIncompleteRune(b []byte) ([]byte, []byte) {
+	for i := len(b) - 1; i >= max(len(b)-utf8.UTFMax+1, 0); i-- {
+		if utf8.RuneStart(b[i]) {
+			if b[i] >= utf8.RuneSelf && !utf8.FullRune(b[i:]) {
+				return b[:i], b[i:]
+			}
+			break
+		}
+	}
+	return b, nil
+}
+
+func parseKeys(b []byte) []Key {
+	keys := make([]Key, 0, len(b))
+	for len(b) > 0 {
+		switch {
+		case b[0] == 0x1b && len(b) >= 3 && (b[1] == '[' || b[1] == 'O'):
+			switch b[2] {
+			case 'A':
+				keys = append(keys, Key{Code: KeyUp})
+			case 'B':
+				keys = append(keys, Key{Code: KeyDown})
+			case 'C':
+				keys = append(keys, Key{Code: KeyRight})
+			case 'D':
+				keys = append(keys, Key{Code: KeyLeft})
+			}
+			// skip the parameters of longer sequences up to their final byte
+			n := 2
+			for n < len(b) && (b[n] < 0x40 || b[n] > 0x7e) {
+				n++
+			}
+			b = b[min(n+1, len(b)):]
+			continue
+		case b[0] == 0x1b:
+			keys = append(keys, Key{Code: KeyEscape})
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case b[0] == '\t':
+			keys = append(keys, Key{Code: KeyTab})
+		case b[0] == 0x7f || b[0] == 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		case b[0] == 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		case b[0] >= 0x20:
+			r, size := utf8.DecodeRune(b)
+			if r != utf8.RuneError {
+				keys = append(keys, RuneKey(r))
+			}
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
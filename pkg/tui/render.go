@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// returns the lines of the screen for a terminal of the given size: the stats,
+// the node list next to the edges of the selected node, a message and the
+// command line
+func (e *Editor) Render(width, height int) []string {
+	width = max(width, 20)
+	height = max(height, 6)
+	lines := make([]string, 0, height)
+	lines = append(lines, fit(e.stats(), width))
+
+	rows := height - 4
+	leftWidth := max(width/3, 12)
+	rightWidth := width - leftWidth - 1
+	left := e.renderNodes(rows)
+	right := e.renderEdges(rows)
+	lines = append(lines, fit("nodes", leftWidth)+" "+fit(e.edgesTitle(), rightWidth))
+	for i := range rows {
+		lines = append(lines, fit(left[i], leftWidth)+" "+fit(right[i], rightWidth))
+	}
+
+	lines = append(lines, fit(e.message, width))
+	switch e.mode {
+	case commandMode:
+		lines = append(lines, fit(":"+string(e.input)+"_", width))
+	case weightMode:
+		lines = append(lines, fit("weight: "+string(e.input)+"_   [enter] save  [esc] cancel", width))
+	default:
+		lines = append(lines, fit("[:] command  [w] weight  [d] delete  [u/r] undo/redo  [q] quit", width))
+	}
+	return lines
+}
+
+// returns the live stats of the graph
+func (e *Editor) stats() string {
+	g := e.graph()
+	edges := g.GetAllEdges()
+	weight := 0
+	for _, edge := range edges {
+		weight += edge.Weight
+	}
+	return fmt.Sprintf("nodes %d  edges %d  weight %d  components %d  odd nodes %d",
+		len(g.GetAllNodes()), len(edges)/2, weight/2, len(g.ConnectedComponents()), len(g.GetAllOddNodes()))
+}
+
+func (e *Editor) edgesTitle() string {
+	node, ok := e.selectedNode()
+	if !ok {
+		return "no nodes, add one with :n <id>"
+	}
+	return fmt.Sprintf("edges of %s%s", node.Id, formatName(node.Name))
+}
+
+// returns a line for each row with the nodes and their degree
+func (e *Editor) renderNodes(rows int) []string {
+	lines := make([]string, rows)
+	nodes := e.graph().GetAllNodes()
+	first := scroll(e.node, len(nodes), rows)
+	for i := range rows {
+		if first+i >= len(nodes) {
+			break
+		}
+		node := nodes[first+i]
+		lines[i] = marker(first+i == e.node, e.focus == nodesPane) + fmt.Sprintf("%s (%d)", node.Id, e.graph().Degree(node))
+	}
+	return lines
+}
+
+// returns a line for each row with the edges of the selected node
+func (e *Editor) renderEdges(rows int) []string {
+	lines := make([]string, rows)
+	edges := e.selectedEdges()
+	if len(edges) > 0 {
+		e.edge = min(max(e.edge, 0), len(edges)-1)
+	}
+	first := scroll(e.edge, len(edges), rows)
+	for i := range rows {
+		if first+i >= len(edges) {
+			break
+		}
+		edge := edges[first+i]
+		selected := first+i == e.edge
+		weight := fmt.Sprint(edge.Weight)
+		if selected && e.mode == weightMode {
+			weight = string(e.input) + "_"
+		}
+		lines[i] = marker(selected, e.focus == edgesPane) + fmt.Sprintf("%s [%d] (%s)%s", edge.To.Id, edge.Id, weight, formatName(edge.Name))
+	}
+	return lines
+}
+
+// returns the first row to show so the selected one is visible
+func scroll(selected, total, rows int) int {
+	if total <= rows || selected < rows {
+		return 0
+	}
+	return min(selected-rows+1, total-rows)
+}
+
+// returns the prefix of a row, the selection is marked differently when its pane is not focused
+func marker(selected, focused bool) string {
+	if !selected {
+		return "  "
+	}
+	if focused {
+		return "> "
+	}
+	return "- "
+}
+
+func formatName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return " " + name
+}
+
+// pads or cuts s to exactly width chars
+func fit(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		return string([]rune(s)[:width])
+	}
+	return s + strings.Repeat(" ", width-n)
+}
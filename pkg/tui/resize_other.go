@@ -0,0 +1,11 @@
+//go:build !unix
+
+package tui
+
+import (
+	"os"
+)
+
+// terminals outside unix don't signal resizes, the screen is redrawn with the
+// new size after the next key
+func notifyResize(c chan<- os.Signal) {}
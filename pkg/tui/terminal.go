@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"errors"
+	"graph/pkg/graph"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+var (
+	ErrNotTerminal = errors.New("the editor needs a terminal")
+)
+
+// time to wait for the rest of an escape sequence before taking the escape
+// byte as the escape key
+const escapeDelay = 50 * time.Millisecond
+
+const (
+	enterAlternateScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAlternateScreen = "\x1b[?25h\x1b[?1049l"
+	cursorHome           = "\x1b[H"
+	clearLine            = "\x1b[K"
+)
+
+// runs the full screen editor on the terminal until the user leaves it, the
+// screen is redrawn after every key and when the terminal is resized, the
+// terminal is restored afterwards
+func Run(h *graph.History) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return ErrNotTerminal
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+	os.Stdout.WriteString(enterAlternateScreen)
+	defer os.Stdout.WriteString(leaveAlternateScreen)
+
+	resized := make(chan os.Signal, 1)
+	notifyResize(resized)
+	defer signal.Stop(resized)
+
+	e := NewEditor(h)
+	buf := make([]byte, 256)
+	// start of a char whose remaining bytes were not read yet
+	pending := make([]byte, 0, utf8.UTFMax)
+	reads := make(chan input, 1)
+	readAsync(os.Stdin, buf, reads)
+	// fires when the escape held in pending was not followed by the rest of a
+	// sequence, so it was the escape key
+	var escape <-chan time.Time
+	for !e.Done() {
+		width, height, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			width, height = 80, 24
+		}
+		err = draw(os.Stdout, e.Render(width, height))
+		if err != nil {
+			return err
+		}
+		select {
+		case <-resized:
+		case <-escape:
+			for _, k := range ParseKeys(pending) {
+				e.HandleKey(k)
+			}
+			pending = pending[:0]
+			escape = nil
+		case in := <-reads:
+			if in.err != nil {
+				return in.err
+			}
+			keys, rest := ParseKeysPartial(append(pending, in.b...))
+			pending = append(pending[:0], rest...)
+			for _, k := range keys {
+				e.HandleKey(k)
+			}
+			escape = nil
+			if len(pending) > 0 && pending[0] == 0x1b {
+				escape = time.After(escapeDelay)
+			}
+			// no read is left waiting once the editor is done, so the keys
+			// typed afterwards go to whoever reads next
+			if !e.Done() {
+				readAsync(os.Stdin, buf, reads)
+			}
+		}
+	}
+	return nil
+}
+
+// bytes of a single read and its error
+type input struct {
+	b   []byte
+	err error
+}
+
+// reads once from r into buf in the background and sends the result to c
+func readAsync(r io.Reader, buf []byte, c chan<- input) {
+	go func() {
+		n, err := r.Read(buf)
+		c <- input{b: buf[:n], err: err}
+	}()
+}
+
+// writes the lines over the previous screen, raw mode needs explicit carriage returns
+func draw(w io.Writer, lines []string) error {
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString(clearLine)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}